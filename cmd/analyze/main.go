@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	level1   = flag.String("level1", "intermediate", "Level of the first robot: beginner, casual, intermediate, advanced or expert")
+	level2   = flag.String("level2", "expert", "Level of the second robot")
+	seed     = flag.Int64("seed", 0, "Random seed of the game; the current time if zero")
+	jsonFile = flag.String("json", "", "Export the analysis to this JSON file")
+)
+
+var levels = map[string]scrabble.Difficulty{
+	"beginner":     scrabble.Beginner,
+	"casual":       scrabble.Casual,
+	"intermediate": scrabble.Intermediate,
+	"advanced":     scrabble.Advanced,
+	"expert":       scrabble.Expert,
+}
+
+func main() {
+	flag.Parse()
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
+
+	// Play a game between two robots, then analyze it
+	g := scrabble.NewGame(scrabble.DefaultTileSet, scrabble.NewDawg(dict))
+	bots := [2]*scrabble.Bot{}
+	for i, level := range []string{*level1, *level2} {
+		d, ok := levels[strings.ToLower(level)]
+		if !ok {
+			log.Fatalf("unknown level %q", level)
+		}
+		name := fmt.Sprintf("%s-%d", level, i+1)
+		bots[i] = scrabble.NewBot(scrabble.NewPlayer(name, g.Bag), scrabble.NewDifficultyStrategy(d, nil))
+		g.Players[i] = bots[i].Player
+	}
+	for !g.IsOver() {
+		move := bots[g.PlayerToMoveIndex()].GenerateMove(g.State())
+		if err := g.ApplyValid(move); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Printf("Game played with seed %v\n\n", *seed)
+	analysis := (&scrabble.Analyzer{}).Analyze(g)
+	if err := analysis.WriteReport(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	if *jsonFile != "" {
+		data, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*jsonFile, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile      = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	positionsFile = flag.String("positions", "", "Board snapshots to benchmark; generated by self-play if empty")
+	numGames      = flag.Int("games", 5, "Number of self-play games to generate the positions from")
+	saveFile      = flag.String("save", "", "Save the generated positions to this file")
+	iterations    = flag.Int("n", 10, "Number of times the moves of every position are generated")
+	axes          = flag.Bool("axes", false, "Report the time spent on each axis")
+	cpuProfile    = flag.String("cpuprofile", "", "Write a CPU profile to this file")
+	memProfile    = flag.String("memprofile", "", "Write a heap profile to this file")
+)
+
+func main() {
+	flag.Parse()
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tileSet := scrabble.DefaultTileSet
+	dawg := scrabble.NewDawg(dict)
+
+	var snapshots []*scrabble.Snapshot
+	if *positionsFile != "" {
+		snapshots, err = scrabble.LoadSnapshots(*positionsFile, tileSet)
+	} else {
+		snapshots = selfPlay(tileSet, dawg, *numGames)
+		if *saveFile != "" {
+			err = scrabble.SaveSnapshots(*saveFile, snapshots)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(snapshots) == 0 {
+		log.Fatal("no positions to benchmark")
+	}
+	states := make([]*scrabble.GameState, len(snapshots))
+	for i, snapshot := range snapshots {
+		states[i] = snapshot.State(dawg, tileSet)
+	}
+
+	// Warm up the DAWG caches, so that the first
+	// iteration is not slower than the others
+	for _, state := range states {
+		state.GenerateMoves()
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			log.Fatal(err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	benchmark(states, *iterations)
+	if *axes {
+		benchmarkAxes(states, *iterations)
+	}
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			log.Fatal(err)
+		}
+	}
+	nodes, cross := dawg.CacheStats()
+	fmt.Printf("DAWG node cache: %+v\nDAWG cross-check cache: %+v\n", nodes, cross)
+}
+
+// selfPlay plays games between two robots playing the highest scoring
+// move and returns the positions they had to move in
+func selfPlay(tileSet *scrabble.TileSet, dawg *scrabble.DAWG, numGames int) []*scrabble.Snapshot {
+	snapshots := make([]*scrabble.Snapshot, 0)
+	for i := 0; i < numGames; i++ {
+		g := scrabble.NewGame(tileSet, dawg)
+		bot1 := scrabble.NewBot(scrabble.NewPlayer("Alphonse", g.Bag), &scrabble.HighScore{})
+		bot2 := scrabble.NewBot(scrabble.NewPlayer("Sylvestre", g.Bag), &scrabble.HighScore{})
+		g.Players[0], g.Players[1] = bot1.Player, bot2.Player
+		bots := [2]*scrabble.Bot{bot1, bot2}
+
+		for !g.IsOver() {
+			state := g.State()
+			snapshots = append(snapshots, scrabble.NewSnapshot(state))
+			move := bots[g.PlayerToMoveIndex()].GenerateMove(state)
+			if err := g.ApplyValid(move); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	return snapshots
+}
+
+// benchmark generates the moves of every position the given number of
+// times and reports the throughput and the allocations
+func benchmark(states []*scrabble.GameState, iterations int) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	numMoves := 0
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		for _, state := range states {
+			numMoves += len(state.GenerateMoves())
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	calls := iterations * len(states)
+	fmt.Printf("%v positions, %v calls to GenerateMoves in %v\n", len(states), calls, elapsed)
+	fmt.Printf("%.0f moves/sec, %.0f calls/sec, %v per call\n",
+		float64(numMoves)/elapsed.Seconds(),
+		float64(calls)/elapsed.Seconds(),
+		elapsed/time.Duration(calls),
+	)
+	fmt.Printf("%v allocs/call, %v bytes/call\n",
+		(after.Mallocs-before.Mallocs)/uint64(calls),
+		(after.TotalAlloc-before.TotalAlloc)/uint64(calls),
+	)
+}
+
+// benchmarkAxes generates the moves of every position one axis at a
+// time, and reports the time spent on each axis, from the slowest
+func benchmarkAxes(states []*scrabble.GameState, iterations int) {
+	type axisTiming struct {
+		index      int
+		horizontal bool
+		elapsed    time.Duration
+		numMoves   int
+	}
+	timings := make([]*axisTiming, 0, scrabble.BoardSize*2)
+	for index := 0; index < scrabble.BoardSize; index++ {
+		for _, horizontal := range []bool{true, false} {
+			timings = append(timings, &axisTiming{index: index, horizontal: horizontal})
+		}
+	}
+
+	resultsChan := make(chan []scrabble.Move, 1)
+	for i := 0; i < iterations; i++ {
+		for _, state := range states {
+			leftParts := state.DAWG.FindLeftParts(state.Rack.AsString())
+			for _, timing := range timings {
+				start := time.Now()
+				state.GenerateMovesOnAxis(timing.index, timing.horizontal, leftParts, resultsChan)
+				moves := <-resultsChan
+				timing.elapsed += time.Since(start)
+				timing.numMoves += len(moves)
+			}
+		}
+	}
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].elapsed > timings[j].elapsed
+	})
+	calls := time.Duration(iterations * len(states))
+	fmt.Println("Time per call spent on each axis:")
+	for _, timing := range timings {
+		kind := "column"
+		if timing.horizontal {
+			kind = "row"
+		}
+		fmt.Printf("  %-6s %2d  %10v  %6.1f moves\n",
+			kind, timing.index, timing.elapsed/calls, float64(timing.numMoves)/float64(calls))
+	}
+}
@@ -3,12 +3,18 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log"
 	"time"
 
 	"scrabble/pkg/scrabble"
 )
 
-var numGames = flag.Int("n", 10, "Number of games to simulate")
+var (
+	numGames   = flag.Int("n", 10, "Number of games to simulate")
+	leavesFile = flag.String("leaves", "", "Leave table used by the robots to evaluate their moves")
+	trainFile  = flag.String("train", "", "Train rack leave values from the simulated games and save them to this file")
+	minSamples = flag.Int("min-samples", 100, "Minimum number of times a leave must be seen to be saved when training")
+)
 
 func main() {
 	start := time.Now()
@@ -19,10 +25,27 @@ func main() {
 
 	dawg := scrabble.NewDawg(dict)
 
+	// Robots play the highest scoring move, unless they are
+	// given a leave table to evaluate their moves with
+	var strategy scrabble.Strategy = &scrabble.HighScore{}
+	if *leavesFile != "" {
+		leaves, err := scrabble.LoadLeaveTable(*leavesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		leaves.Fallback = scrabble.DefaultLeaves
+		strategy = &scrabble.StaticEval{Leaves: leaves}
+	}
+
+	var trainer *scrabble.LeaveTrainer
+	if *trainFile != "" {
+		trainer = scrabble.NewLeaveTrainer()
+	}
+
 	var winsA, winsB int
 
 	for i := 0; i < *numGames; i++ {
-		scoreA, scoreB := simulateGame(tileSet, dawg)
+		scoreA, scoreB := simulateGame(tileSet, dawg, strategy, trainer)
 		if scoreA > scoreB {
 			winsA++
 		}
@@ -31,6 +54,13 @@ func main() {
 		}
 	}
 
+	if trainer != nil {
+		if err := trainer.Table(*minSamples).Save(*trainFile); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Leave values trained on %v games were saved to %s\n", trainer.Games(), *trainFile)
+	}
+
 	elapsed := time.Since(start)
 	fmt.Printf("%v games were played\nRobot A won %v games, and Robot B won %v games; %v games were draws.\n",
 		*numGames,
@@ -41,11 +71,11 @@ func main() {
 	fmt.Println("Took", elapsed)
 }
 
-func simulateGame(tileSet *scrabble.TileSet, dawg *scrabble.DAWG) (scoreA, scoreB int) {
+func simulateGame(tileSet *scrabble.TileSet, dawg *scrabble.DAWG, strategy scrabble.Strategy, trainer *scrabble.LeaveTrainer) (scoreA, scoreB int) {
 	g := scrabble.NewGame(tileSet, dawg)
 
-	bot1 := scrabble.NewBot(scrabble.NewPlayer("Alphonse", g.Bag), &scrabble.HighScore{})
-	bot2 := scrabble.NewBot(scrabble.NewPlayer("Sylvestre", g.Bag), &scrabble.HighScore{})
+	bot1 := scrabble.NewBot(scrabble.NewPlayer("Alphonse", g.Bag), strategy)
+	bot2 := scrabble.NewBot(scrabble.NewPlayer("Sylvestre", g.Bag), strategy)
 	g.Players[0], g.Players[1] = bot1.Player, bot2.Player
 
 	for i := 0; ; i++ {
@@ -65,6 +95,10 @@ func simulateGame(tileSet *scrabble.TileSet, dawg *scrabble.DAWG) (scoreA, score
 			break
 		}
 	}
+	if trainer != nil {
+		// Learn from the leaves kept during this game
+		trainer.AddGame(g)
+	}
 	scoreA, scoreB = g.Players[0].Score, g.Players[1].Score
 	return scoreA, scoreB
 }
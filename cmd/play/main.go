@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"scrabble/pkg/render"
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile  = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	level     = flag.String("level", "intermediate", "Level of the robot: beginner, casual, intermediate, advanced or expert")
+	freqFile  = flag.String("frequencies", "", "Word frequency list limiting the vocabulary of the robot")
+	name      = flag.String("name", "You", "Your name")
+	first     = flag.String("first", "random", "Who moves first: human, bot or random")
+	noColor   = flag.Bool("no-color", os.Getenv("NO_COLOR") != "", "Do not use colors")
+	hintCount = flag.Int("hints", 5, "Number of moves suggested by the moves command")
+	penalty   = flag.Int("hint-penalty", 0, "Points deducted for each hint, which disables the moves command")
+	analyze   = flag.Bool("analyze", true, "Analyze the moves of both players once the game is over")
+)
+
+var levels = map[string]scrabble.Difficulty{
+	"beginner":     scrabble.Beginner,
+	"casual":       scrabble.Casual,
+	"intermediate": scrabble.Intermediate,
+	"advanced":     scrabble.Advanced,
+	"expert":       scrabble.Expert,
+}
+
+const help = `Commands:
+  8H word        play a word across from row 8, column H
+  H8 word        play a word down from column H, row 8
+                 Letters already on the board are part of the word;
+                 uppercase letters are played with a blank, unless
+                 the whole word is in capitals
+  exchange abc   exchange tiles, ? being a blank
+  pass           pass your turn
+  hint           get a hint about the best move, each hint of a
+                 turn telling more: bingo, score, square, then word
+  moves [n]      show the n highest scoring moves, unless
+                 the hints cost points
+  check word     tell whether a word is valid, and its hooks
+  board          show the board again
+  save file      save the board to a PNG or SVG file
+  help           show this help
+  quit           leave the game`
+
+func main() {
+	flag.Parse()
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	difficulty, ok := levels[strings.ToLower(*level)]
+	if !ok {
+		log.Fatalf("unknown level %q", *level)
+	}
+	var frequencies *scrabble.WordFrequencies
+	if *freqFile != "" {
+		var err error
+		if frequencies, err = scrabble.LoadWordFrequencies(*freqFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := scrabble.NewGame(scrabble.DefaultTileSet, scrabble.NewDawg(dict))
+	if *penalty > 0 {
+		g.HintPenalties = map[scrabble.HintLevel]int{
+			scrabble.HintBingo:  *penalty,
+			scrabble.HintScore:  *penalty,
+			scrabble.HintSquare: *penalty,
+			scrabble.HintWord:   *penalty,
+		}
+	}
+	human := scrabble.NewPlayer(*name, g.Bag)
+	bot := scrabble.NewBot(scrabble.NewPlayer("Robot", g.Bag), scrabble.NewDifficultyStrategy(difficulty, frequencies))
+	humanFirst := rng.Intn(2) == 0
+	switch *first {
+	case "human":
+		humanFirst = true
+	case "bot":
+		humanFirst = false
+	}
+	if humanFirst {
+		g.Players[0], g.Players[1] = human, bot.Player
+	} else {
+		g.Players[0], g.Players[1] = bot.Player, human
+	}
+
+	c := &client{game: g, human: human, bot: bot, color: !*noColor, in: bufio.NewScanner(os.Stdin)}
+	fmt.Printf("%s vs %s (%s). Type help for the commands.\n", human.Username, bot.Username, *level)
+	c.play()
+}
+
+// client plays a game between a human in the terminal and a robot
+type client struct {
+	game  *scrabble.Game
+	human *scrabble.Player
+	bot   *scrabble.Bot
+	color bool
+	in    *bufio.Scanner
+	// last holds the covers of the last tile move, to highlight it
+	last scrabble.Covers
+	// hintLevel is the level of the next hint of the turn
+	hintLevel scrabble.HintLevel
+}
+
+func (c *client) play() {
+	g := c.game
+	c.show()
+	for !g.IsOver() {
+		if g.PlayerToMove() == c.bot.Player {
+			c.botMove()
+			c.show()
+			continue
+		}
+		if !c.humanMove() {
+			fmt.Println("Bye!")
+			return
+		}
+	}
+	c.gameOver()
+}
+
+// show writes the board, the scores and the human's rack
+func (c *client) show() {
+	g := c.game
+	fmt.Println()
+	render.ANSI(os.Stdout, g.Board, c.options())
+	fmt.Printf("%s %d - %s %d, %d tiles in the bag\n",
+		g.Players[0].Username, g.Players[0].Score,
+		g.Players[1].Username, g.Players[1].Score,
+		g.Bag.TileCount())
+	fmt.Print("Your rack: ")
+	render.Rack(os.Stdout, c.human.Rack, c.options())
+	fmt.Println()
+}
+
+// options returns the rendering options of the board
+func (c *client) options() *render.Options {
+	return &render.Options{LastMove: c.last, NoColor: !c.color}
+}
+
+// save writes the board to a PNG or SVG file, depending on its extension
+func (c *client) save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	opts := c.options()
+	if strings.HasSuffix(strings.ToLower(path), ".svg") {
+		err = render.SVG(f, c.game.Board, opts)
+	} else {
+		err = render.PNG(f, c.game.Board, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// botMove lets the robot play and describes its move
+func (c *client) botMove() {
+	g := c.game
+	state := g.State()
+	move := c.bot.GenerateMove(state)
+	score := move.Score(state)
+	description := ""
+	switch m := move.(type) {
+	case *scrabble.TileMove:
+		description = fmt.Sprintf("plays %s for %d points", m.Notation(g.Board), score)
+	case *scrabble.ExchangeMove:
+		description = fmt.Sprintf("exchanges %d tiles", len([]rune(m.Letters)))
+	default:
+		description = "passes"
+	}
+	if err := g.ApplyValid(move); err != nil {
+		log.Fatal(err)
+	}
+	c.setLast(move)
+	fmt.Printf("\n%s %s\n", c.bot.Username, description)
+}
+
+// humanMove reads commands until the human has moved, and returns
+// false if they quit the game
+func (c *client) humanMove() bool {
+	g := c.game
+	for {
+		fmt.Print("> ")
+		if !c.in.Scan() {
+			return false
+		}
+		fields := strings.Fields(c.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var move scrabble.Move
+		switch command := strings.ToLower(fields[0]); command {
+		case "help", "?":
+			fmt.Println(help)
+			continue
+		case "quit", "exit":
+			return false
+		case "board":
+			c.show()
+			continue
+		case "save":
+			if len(fields) != 2 {
+				fmt.Println("Give the file to save the board to, e.g. save board.png")
+				continue
+			}
+			if err := c.save(fields[1]); err != nil {
+				fmt.Println(err)
+			}
+			continue
+		case "hint":
+			hint := g.Hint(c.hintLevel)
+			if c.hintLevel < scrabble.HintWord {
+				c.hintLevel++
+			}
+			fmt.Println(hint.Text)
+			if hint.Penalty > 0 {
+				fmt.Printf("This hint costs %d points\n", hint.Penalty)
+			}
+			continue
+		case "moves":
+			if c.hintsCost() {
+				fmt.Println("The moves command is disabled when hints cost points")
+				continue
+			}
+			n := *hintCount
+			if len(fields) > 1 {
+				if n, _ = strconv.Atoi(fields[1]); n < 1 {
+					fmt.Println("The number of moves must be positive")
+					continue
+				}
+			}
+			c.moves(n)
+			continue
+		case "check":
+			if len(fields) != 2 {
+				fmt.Println("Give the word to check, e.g. check qi")
+				continue
+			}
+			c.check(fields[1])
+			continue
+		case "pass":
+			move = scrabble.NewPassMove()
+		case "exchange":
+			if len(fields) != 2 {
+				fmt.Println("Give the letters to exchange, e.g. exchange qv")
+				continue
+			}
+			move = scrabble.NewExchangeMove(strings.ReplaceAll(strings.ToLower(fields[1]), "?", "*"))
+			if !move.IsValid(g) {
+				fmt.Println("You cannot exchange these tiles")
+				continue
+			}
+		default:
+			if len(fields) != 2 {
+				fmt.Println("Unknown command; type help for the commands")
+				continue
+			}
+			word := fields[1]
+			if word == strings.ToUpper(word) {
+				// Typed in capitals: there are no blanks
+				word = strings.ToLower(word)
+			}
+			tileMove, err := scrabble.ParseTileMove(g.Board, fields[0], word)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if !onRack(tileMove.Covers, c.human.Rack) {
+				fmt.Println("You do not have these tiles")
+				continue
+			}
+			if !tileMove.IsValid(g) {
+				fmt.Println("This move is not valid")
+				continue
+			}
+			move = tileMove
+		}
+
+		score := move.Score(g.State())
+		if err := g.ApplyValid(move); err != nil {
+			log.Fatal(err)
+		}
+		c.setLast(move)
+		c.hintLevel = scrabble.HintBingo
+		if _, ok := move.(*scrabble.TileMove); ok {
+			fmt.Printf("%d points\n", score)
+		}
+		if item := g.MoveList[len(g.MoveList)-1]; item.Penalty > 0 {
+			fmt.Printf("%d points deducted for %d hints\n", item.Penalty, len(item.Hints))
+		}
+		return true
+	}
+}
+
+// hintsCost returns true if hints cost points, in which case the
+// moves command would give away for free what they charge for
+func (c *client) hintsCost() bool {
+	for _, penalty := range c.game.HintPenalties {
+		if penalty != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// moves writes the n highest scoring moves of the human
+func (c *client) moves(n int) {
+	g := c.game
+	moves := g.State().GenerateTopMoves(n)
+	if len(moves) == 0 {
+		fmt.Println("No tile move: exchange or pass")
+		return
+	}
+	for _, move := range moves {
+		tileMove := move.(*scrabble.TileMove)
+		fmt.Printf("  %-20s %3d\n", tileMove.Notation(g.Board), *tileMove.CachedScore)
+	}
+}
+
+// check tells whether the word is in the dictionary, with the
+// letters that can be put in front of it or after it
+func (c *client) check(word string) {
+	word = strings.ToLower(word)
+	if c.game.DAWG.IsWord(word) {
+		fmt.Printf("%s is valid\n", strings.ToUpper(word))
+	} else {
+		fmt.Printf("%s is not valid\n", strings.ToUpper(word))
+	}
+	front, back := c.game.DAWG.Hooks(word)
+	if len(front) > 0 || len(back) > 0 {
+		fmt.Printf("Hooks: %s %s %s\n", hookLetters(front), strings.ToUpper(word), hookLetters(back))
+	}
+}
+
+// hookLetters returns the hook letters in capitals, or a dash if none
+func hookLetters(letters []rune) string {
+	if len(letters) == 0 {
+		return "-"
+	}
+	return strings.ToUpper(string(letters))
+}
+
+// setLast remembers the covers of the move to highlight
+// them, if it is a tile move
+func (c *client) setLast(move scrabble.Move) {
+	if tileMove, ok := move.(*scrabble.TileMove); ok {
+		c.last = tileMove.Covers
+	}
+}
+
+// gameOver writes the final board and scores
+func (c *client) gameOver() {
+	g := c.game
+	c.show()
+	fmt.Println("\nGame over")
+	for i, item := range g.MoveList {
+		// The player of a move is given by its parity
+		if final, ok := item.Move.(*scrabble.FinalMove); ok && final.OpponentRack != "" {
+			fmt.Printf("  %s gets %d points from the rack %s\n",
+				g.Players[i%2].Username, final.Score(g.State()), final.OpponentRack)
+		}
+	}
+	a, b := g.Players[0], g.Players[1]
+	switch {
+	case a.Score > b.Score:
+		fmt.Printf("%s wins %d to %d\n", a.Username, a.Score, b.Score)
+	case b.Score > a.Score:
+		fmt.Printf("%s wins %d to %d\n", b.Username, b.Score, a.Score)
+	default:
+		fmt.Printf("Draw, %d all\n", a.Score)
+	}
+	if *analyze {
+		fmt.Println()
+		(&scrabble.Analyzer{}).Analyze(g).WriteReport(os.Stdout)
+	}
+}
+
+// onRack returns true if the rack holds the tiles of the covers
+func onRack(covers scrabble.Covers, rack *scrabble.Rack) bool {
+	letters := rack.AsString()
+	for _, cover := range covers {
+		if !strings.ContainsRune(letters, cover.Letter) {
+			return false
+		}
+		letters = strings.Replace(letters, string(cover.Letter), "", 1)
+	}
+	return true
+}
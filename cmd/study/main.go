@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"scrabble/pkg/scrabble"
+	"scrabble/pkg/study"
+)
+
+var (
+	dictFile = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	storeDir = flag.String("store", ".study", "Directory keeping the progress and the sessions")
+	user     = flag.String("user", defaultUser(), "Name of the user whose progress is kept")
+	length   = flag.Int("length", 7, "Length of the words")
+	count    = flag.Int("count", 20, "Number of questions of the session")
+	fromRank = flag.Int("from", 1, "Only ask new alphagrams from this probability rank")
+	toRank   = flag.Int("to", 0, "Only ask new alphagrams up to this probability rank, all if zero")
+	resume   = flag.String("resume", "", "ID of a session to go on with")
+	countAll = flag.Bool("count-answers", true, "Tell how many words each alphagram makes")
+)
+
+func defaultUser() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	return "guest"
+}
+
+func main() {
+	flag.Parse()
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	store, err := study.NewFileStore(*storeDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	quiz := study.NewQuiz(study.NewLexicon(scrabble.NewDawg(dict), scrabble.DefaultTileSet), store)
+	quiz.FromRank, quiz.ToRank = *fromRank, *toRank
+
+	var s *study.Session
+	if *resume != "" {
+		s, err = quiz.Resume(*resume)
+	} else {
+		s, err = quiz.Start(*user, *length, *count, time.Now())
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Type the words made of all the letters, separated by spaces;")
+	fmt.Println("an empty line gives up, quit stops the session.")
+	fmt.Println()
+	in := bufio.NewScanner(os.Stdin)
+	for question := s.Current(); question != nil; question = s.Current() {
+		fmt.Printf("%d/%d  %s  (#%d)", len(s.Answers)+1, len(s.Questions), strings.ToUpper(question.Alphagram), question.Rank)
+		if *countAll {
+			if n := len(question.Answers); n == 1 {
+				fmt.Print("  1 word")
+			} else {
+				fmt.Printf("  %d words", n)
+			}
+		}
+		fmt.Print("\n> ")
+		if !in.Scan() {
+			fmt.Println()
+			break
+		}
+		guesses := strings.Fields(in.Text())
+		if len(guesses) == 1 && strings.EqualFold(guesses[0], "quit") {
+			break
+		}
+
+		answer, err := quiz.Answer(s, guesses, time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if answer.IsRight() {
+			fmt.Println("Right!")
+		} else {
+			printWords("Missed", answer.Missed)
+			printWords("Wrong", answer.Wrong)
+		}
+		fmt.Println()
+	}
+
+	right, answered := s.Score()
+	fmt.Printf("%d of %d right\n", right, answered)
+	if s.Current() != nil {
+		fmt.Printf("Go on with this session with -resume %s\n", s.ID)
+	}
+}
+
+// printWords prints a list of words in capitals, if any
+func printWords(label string, words []string) {
+	if len(words) > 0 {
+		fmt.Printf("%s: %s\n", label, strings.ToUpper(strings.Join(words, " ")))
+	}
+}
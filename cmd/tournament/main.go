@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	botSpecs = flag.String("bots", "highscore,static", "Comma separated strategies taking part in the tournament")
+	freqFile = flag.String("frequencies", "", "Word frequency list limiting the vocabulary of the difficulty levels")
+	pairings = flag.String("pairing", "roundrobin", "Pairing system: roundrobin or swiss")
+	rounds   = flag.Int("rounds", 3, "Number of rounds of a Swiss tournament")
+	numGames = flag.Int("games", 10, "Number of games of each pairing, the first player alternating")
+	parallel = flag.Int("parallel", runtime.NumCPU(), "Number of games played at the same time")
+	csvFile  = flag.String("csv", "", "Export the game records to this CSV file")
+	jsonFile = flag.String("json", "", "Export the standings and the game records to this JSON file")
+)
+
+func main() {
+	start := time.Now()
+	flag.Parse()
+
+	var frequencies *scrabble.WordFrequencies
+	if *freqFile != "" {
+		var err error
+		if frequencies, err = scrabble.LoadWordFrequencies(*freqFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	entrants := make([]*Entrant, 0)
+	seen := make(map[string]int)
+	for _, spec := range strings.Split(*botSpecs, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		strategy, err := parseStrategy(spec, frequencies)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Number the entrants sharing a strategy
+		name := spec
+		if seen[spec]++; seen[spec] > 1 {
+			name = fmt.Sprintf("%s#%d", spec, seen[spec])
+		}
+		entrants = append(entrants, &Entrant{Name: name, Strategy: strategy})
+	}
+	if len(entrants) < 2 {
+		log.Fatal("a tournament needs at least two strategies")
+	}
+	if *numGames < 1 || *parallel < 1 {
+		log.Fatal("the number of games and of parallel games must be positive")
+	}
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := &Tournament{
+		Entrants:        entrants,
+		GamesPerPairing: *numGames,
+		Parallel:        *parallel,
+		TileSet:         scrabble.DefaultTileSet,
+		DAWG:            scrabble.NewDawg(dict),
+	}
+
+	switch *pairings {
+	case "roundrobin":
+		t.RoundRobin()
+	case "swiss":
+		t.Swiss(*rounds)
+	default:
+		log.Fatalf("unknown pairing system %q", *pairings)
+	}
+
+	standings := t.Standings()
+	printStandings(os.Stdout, standings)
+	if *csvFile != "" {
+		if err := saveCSV(*csvFile, t.Records); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *jsonFile != "" {
+		if err := saveJSON(*jsonFile, standings, t.Records); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Printf("%v games were played in %v\n", len(t.Records), time.Since(start))
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// z is the quantile of the normal distribution for 95% confidence
+const z = 1.96
+
+// wilson returns the 95% Wilson score interval of a win rate over n games
+func wilson(rate, n float64) (low, high float64) {
+	center := (rate + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(rate*(1-rate)/n+z*z/(4*n*n))
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// printStandings writes the standings as a table
+func printStandings(w io.Writer, standings []*Standing) {
+	fmt.Fprintf(w, "%-4s %-24s %6s %5s %5s %5s  %-20s %7s %7s %7s\n",
+		"Rank", "Strategy", "Games", "W", "D", "L", "Win rate (95% CI)", "Score", "Spread", "Bingos")
+	for i, s := range standings {
+		fmt.Fprintf(w, "%-4d %-24s %6d %5d %5d %5d  %5.1f%% [%4.1f-%5.1f%%] %7.1f %+7.1f %7.2f\n",
+			i+1, s.Name, s.Games, s.Wins, s.Draws, s.Losses,
+			s.WinRate*100, s.WinRateLow*100, s.WinRateHigh*100,
+			s.AverageScore, s.AverageSpread, s.BingosPerGame)
+	}
+}
+
+// saveCSV saves the game records to a CSV file, one game per line
+func saveCSV(path string, records []*GameRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{
+		"round", "first", "second", "first_score", "second_score", "spread",
+		"first_bingos", "second_bingos", "moves", "winner", "duration_ms",
+	})
+	for _, r := range records {
+		w.Write([]string{
+			strconv.Itoa(r.Round), r.First, r.Second,
+			strconv.Itoa(r.FirstScore), strconv.Itoa(r.SecondScore), strconv.Itoa(r.Spread),
+			strconv.Itoa(r.FirstBingos), strconv.Itoa(r.SecondBingos), strconv.Itoa(r.Moves),
+			r.Winner, strconv.FormatInt(r.Duration.Milliseconds(), 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// saveJSON saves the standings and the game records to a JSON file
+func saveJSON(path string, standings []*Standing, records []*GameRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(struct {
+		Standings []*Standing   `json:"standings"`
+		Games     []*GameRecord `json:"games"`
+	}{standings, records})
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
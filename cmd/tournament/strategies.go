@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+var difficulties = map[string]scrabble.Difficulty{
+	"beginner":     scrabble.Beginner,
+	"casual":       scrabble.Casual,
+	"intermediate": scrabble.Intermediate,
+	"advanced":     scrabble.Advanced,
+	"expert":       scrabble.Expert,
+}
+
+// parseStrategy returns the strategy described by spec, a strategy
+// name optionally followed by a colon and a parameter:
+//
+//	highscore
+//	oneofnbest[:n]          one of the n best moves, 5 by default
+//	static[:leaves file]    best equity, with default or trained leaves
+//	montecarlo[:budget]     simulations, within a time budget per move
+//	preendgame[:budget]     Monte Carlo, then pre-endgame and endgame search
+//	difficulty:<level>      beginner, casual, intermediate, advanced or expert
+//
+// The difficulty levels limit their vocabulary with the word frequency
+// list, without which they cannot be parsed.
+func parseStrategy(spec string, frequencies *scrabble.WordFrequencies) (scrabble.Strategy, error) {
+	name, param, _ := strings.Cut(spec, ":")
+	name = strings.ToLower(name)
+	switch name {
+	case "highscore":
+		return &scrabble.HighScore{}, nil
+	case "oneofnbest":
+		n := 5
+		if param != "" {
+			var err error
+			if n, err = strconv.Atoi(param); err != nil || n < 1 {
+				return nil, fmt.Errorf("%s: invalid number of moves %q", spec, param)
+			}
+		}
+		return &scrabble.OneOfNBest{N: n}, nil
+	case "static":
+		if param == "" {
+			return &scrabble.StaticEval{}, nil
+		}
+		leaves, err := scrabble.LoadLeaveTable(param)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", spec, err)
+		}
+		leaves.Fallback = scrabble.DefaultLeaves
+		return &scrabble.StaticEval{Leaves: leaves}, nil
+	case "montecarlo", "preendgame":
+		var budget time.Duration
+		if param != "" {
+			var err error
+			if budget, err = time.ParseDuration(param); err != nil {
+				return nil, fmt.Errorf("%s: %w", spec, err)
+			}
+		}
+		mc := &scrabble.MonteCarlo{Budget: budget}
+		if name == "montecarlo" {
+			return mc, nil
+		}
+		return &scrabble.PreEndgame{
+			Budget: budget,
+			// The endgames of the bag draws are solved within
+			// the budget of the whole pre-endgame search...
+			Endgame: &scrabble.EndgameSolver{},
+			// ...and the actual endgame within a budget of its own
+			Fallback: &scrabble.EndgameSolver{Budget: budget, Fallback: mc},
+		}, nil
+	case "difficulty":
+		d, ok := difficulties[strings.ToLower(param)]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown difficulty level %q", spec, param)
+		}
+		if frequencies == nil {
+			return nil, fmt.Errorf("%s: a word frequency list is needed, see -frequencies", spec)
+		}
+		return scrabble.NewDifficultyStrategy(d, frequencies), nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q", spec)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+// Entrant is a strategy taking part in the tournament
+type Entrant struct {
+	Name     string
+	Strategy scrabble.Strategy
+}
+
+// pairing is a match between two entrants, given by their indices
+type pairing struct {
+	a, b int
+}
+
+// GameRecord is the outcome of a tournament game
+type GameRecord struct {
+	Round        int           `json:"round"`
+	First        string        `json:"first"`
+	Second       string        `json:"second"`
+	FirstScore   int           `json:"firstScore"`
+	SecondScore  int           `json:"secondScore"`
+	Spread       int           `json:"spread"`
+	FirstBingos  int           `json:"firstBingos"`
+	SecondBingos int           `json:"secondBingos"`
+	Moves        int           `json:"moves"`
+	Winner       string        `json:"winner"`
+	Duration     time.Duration `json:"durationNs"`
+
+	first, second int
+}
+
+// Tournament plays games between entrants and keeps their records
+type Tournament struct {
+	Entrants []*Entrant
+	// GamesPerPairing is the number of games each pairing plays,
+	// the entrants taking turns to move first
+	GamesPerPairing int
+	// Parallel is the number of games played at the same time
+	Parallel int
+	TileSet  *scrabble.TileSet
+	DAWG     *scrabble.DAWG
+
+	Records []*GameRecord
+	// byes counts the Swiss rounds each entrant sat out
+	byes []int
+}
+
+// RoundRobin plays every entrant against every other one. The pairings
+// are scheduled in rounds with the circle method, so that no entrant
+// plays twice in a round.
+func (t *Tournament) RoundRobin() {
+	n := len(t.Entrants)
+	// Add a dummy entrant to get an even number;
+	// pairings against it are skipped
+	circle := make([]int, 0, n+1)
+	for i := 0; i < n; i++ {
+		circle = append(circle, i)
+	}
+	if n%2 == 1 {
+		circle = append(circle, -1)
+	}
+	for round := 1; round < len(circle); round++ {
+		pairings := make([]pairing, 0, len(circle)/2)
+		for i := 0; i < len(circle)/2; i++ {
+			a, b := circle[i], circle[len(circle)-1-i]
+			if a >= 0 && b >= 0 {
+				pairings = append(pairings, pairing{a: a, b: b})
+			}
+		}
+		t.playRound(round, pairings)
+		// Keep the first entrant in place and rotate the others
+		last := circle[len(circle)-1]
+		copy(circle[2:], circle[1:len(circle)-1])
+		circle[1] = last
+	}
+}
+
+// Swiss plays the given number of rounds, pairing in each round the
+// entrants with the closest standings that have not met yet. With an
+// odd number of entrants, the lowest ranked one that has not had a bye
+// yet sits out the round, which counts as a win for the pairings.
+func (t *Tournament) Swiss(rounds int) {
+	if t.byes == nil {
+		t.byes = make([]int, len(t.Entrants))
+	}
+	for round := 1; round <= rounds; round++ {
+		standings := t.Standings()
+		order := make([]int, len(standings))
+		for i, s := range standings {
+			order[i] = s.index
+		}
+
+		if len(order)%2 == 1 {
+			bye := len(order) - 1
+			for i := len(order) - 1; i >= 0; i-- {
+				if t.byes[order[i]] == 0 {
+					bye = i
+					break
+				}
+			}
+			t.byes[order[bye]]++
+			log.Printf("Round %d: bye for %s", round, t.Entrants[order[bye]].Name)
+			order = append(order[:bye], order[bye+1:]...)
+		}
+
+		met := t.met()
+		pairings := make([]pairing, 0, len(order)/2)
+		paired := make([]bool, len(order))
+		for i := range order {
+			if paired[i] {
+				continue
+			}
+			// The next unpaired entrant not met yet, or
+			// the next unpaired one if all were met
+			opp := -1
+			for j := i + 1; j < len(order); j++ {
+				if paired[j] {
+					continue
+				}
+				if opp < 0 {
+					opp = j
+				}
+				if !met[pairing{a: order[i], b: order[j]}] {
+					opp = j
+					break
+				}
+			}
+			paired[i], paired[opp] = true, true
+			pairings = append(pairings, pairing{a: order[i], b: order[opp]})
+		}
+		t.playRound(round, pairings)
+	}
+}
+
+// met returns the pairings that have played each other, both ways
+func (t *Tournament) met() map[pairing]bool {
+	met := make(map[pairing]bool)
+	for _, r := range t.Records {
+		met[pairing{a: r.first, b: r.second}] = true
+		met[pairing{a: r.second, b: r.first}] = true
+	}
+	return met
+}
+
+// playRound plays GamesPerPairing games for each pairing, up to
+// Parallel games at the same time, and appends their records
+func (t *Tournament) playRound(round int, pairings []pairing) {
+	type job struct {
+		index         int
+		first, second int
+	}
+	jobs := make(chan job)
+	records := make([]*GameRecord, len(pairings)*t.GamesPerPairing)
+
+	var wg sync.WaitGroup
+	for i := 0; i < t.Parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				record := t.playGame(j.first, j.second)
+				record.Round = round
+				records[j.index] = record
+			}
+		}()
+	}
+	for i, p := range pairings {
+		for g := 0; g < t.GamesPerPairing; g++ {
+			// Alternate the first player
+			first, second := p.a, p.b
+			if g%2 == 1 {
+				first, second = second, first
+			}
+			jobs <- job{index: i*t.GamesPerPairing + g, first: first, second: second}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	t.Records = append(t.Records, records...)
+}
+
+// playGame plays a game between two entrants, the first one moving first
+func (t *Tournament) playGame(first, second int) *GameRecord {
+	start := time.Now()
+	g := scrabble.NewGame(t.TileSet, t.DAWG)
+	bot1 := scrabble.NewBot(scrabble.NewPlayer(t.Entrants[first].Name, g.Bag), t.Entrants[first].Strategy)
+	bot2 := scrabble.NewBot(scrabble.NewPlayer(t.Entrants[second].Name, g.Bag), t.Entrants[second].Strategy)
+	g.Players[0], g.Players[1] = bot1.Player, bot2.Player
+	bots := [2]*scrabble.Bot{bot1, bot2}
+
+	var bingos [2]int
+	moves := 0
+	for !g.IsOver() {
+		index := g.PlayerToMoveIndex()
+		move := bots[index].GenerateMove(g.State())
+		if tileMove, ok := move.(*scrabble.TileMove); ok && len(tileMove.Covers) == scrabble.RackSize {
+			bingos[index]++
+		}
+		if err := g.ApplyValid(move); err != nil {
+			log.Fatal(err)
+		}
+		moves++
+	}
+
+	record := &GameRecord{
+		First:        t.Entrants[first].Name,
+		Second:       t.Entrants[second].Name,
+		FirstScore:   bot1.Score,
+		SecondScore:  bot2.Score,
+		Spread:       bot1.Score - bot2.Score,
+		FirstBingos:  bingos[0],
+		SecondBingos: bingos[1],
+		Moves:        moves,
+		Duration:     time.Since(start),
+		first:        first,
+		second:       second,
+	}
+	if record.Spread > 0 {
+		record.Winner = record.First
+	} else if record.Spread < 0 {
+		record.Winner = record.Second
+	}
+	return record
+}
+
+// Standing sums up the games of an entrant
+type Standing struct {
+	Name          string  `json:"name"`
+	Games         int     `json:"games"`
+	Wins          int     `json:"wins"`
+	Draws         int     `json:"draws"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"winRate"`
+	WinRateLow    float64 `json:"winRateLow"`
+	WinRateHigh   float64 `json:"winRateHigh"`
+	AverageScore  float64 `json:"averageScore"`
+	AverageSpread float64 `json:"averageSpread"`
+	BingosPerGame float64 `json:"bingosPerGame"`
+
+	index  int
+	points float64
+}
+
+// Standings returns the standings of the entrants, from the best: by
+// points, draws counting as half a win and Swiss byes as a win, then
+// by average spread
+func (t *Tournament) Standings() []*Standing {
+	standings := make([]*Standing, len(t.Entrants))
+	for i, e := range t.Entrants {
+		standings[i] = &Standing{Name: e.Name, index: i}
+	}
+	score := make([]int, len(t.Entrants))
+	spread := make([]int, len(t.Entrants))
+	bingos := make([]int, len(t.Entrants))
+	for _, r := range t.Records {
+		for _, side := range []struct {
+			index, score, spread, bingos int
+		}{
+			{r.first, r.FirstScore, r.Spread, r.FirstBingos},
+			{r.second, r.SecondScore, -r.Spread, r.SecondBingos},
+		} {
+			s := standings[side.index]
+			s.Games++
+			switch {
+			case side.spread > 0:
+				s.Wins++
+			case side.spread < 0:
+				s.Losses++
+			default:
+				s.Draws++
+			}
+			score[side.index] += side.score
+			spread[side.index] += side.spread
+			bingos[side.index] += side.bingos
+		}
+	}
+	for i, s := range standings {
+		s.points = float64(s.Wins) + float64(s.Draws)/2
+		if t.byes != nil {
+			s.points += float64(t.byes[i])
+		}
+		if s.Games == 0 {
+			continue
+		}
+		games := float64(s.Games)
+		s.WinRate = (float64(s.Wins) + float64(s.Draws)/2) / games
+		s.WinRateLow, s.WinRateHigh = wilson(s.WinRate, games)
+		s.AverageScore = float64(score[i]) / games
+		s.AverageSpread = float64(spread[i]) / games
+		s.BingosPerGame = float64(bingos[i]) / games
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].points != standings[j].points {
+			return standings[i].points > standings[j].points
+		}
+		return standings[i].AverageSpread > standings[j].AverageSpread
+	})
+	return standings
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile      = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	positionsFile = flag.String("positions", "", "Board snapshots to verify; generated by self-play if empty")
+	numGames      = flag.Int("games", 2, "Number of self-play games to generate the positions from")
+	blanks        = flag.Bool("blanks", true, "Also verify every position with a blank tile in the rack")
+	seed          = flag.Int64("seed", 0, "Random seed of the rack tiles replaced by a blank; the current time if zero")
+)
+
+func main() {
+	flag.Parse()
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tileSet := scrabble.DefaultTileSet
+	dawg := scrabble.NewDawg(dict)
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+
+	var snapshots []*scrabble.Snapshot
+	if *positionsFile != "" {
+		snapshots, err = scrabble.LoadSnapshots(*positionsFile, tileSet)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		snapshots = selfPlay(tileSet, dawg, *numGames)
+	}
+
+	if *blanks {
+		fmt.Printf("Blank tiles with seed %v\n", *seed)
+	}
+	failures := 0
+	for i, snapshot := range snapshots {
+		racks := []string{snapshot.Rack}
+		if *blanks && snapshot.Rack != "" {
+			// Replace a random tile of the rack by a blank
+			rack := []rune(snapshot.Rack)
+			rack[rng.Intn(len(rack))] = '*'
+			racks = append(racks, string(rack))
+		}
+		for _, rack := range racks {
+			state := (&scrabble.Snapshot{Board: snapshot.Board, Rack: rack}).State(dawg, tileSet)
+			diff := scrabble.DiffMoveGenerators(state)
+			if diff.OK() {
+				continue
+			}
+			failures++
+			fmt.Printf("Position %d, rack %s: %v\n%v\n", i+1, rack, diff, snapshot.Board)
+		}
+	}
+
+	fmt.Printf("%v positions verified, %v failures\n", len(snapshots), failures)
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// selfPlay plays games between two robots picking one of their ten best
+// moves at random, and returns the positions they had to move in
+func selfPlay(tileSet *scrabble.TileSet, dawg *scrabble.DAWG, numGames int) []*scrabble.Snapshot {
+	snapshots := make([]*scrabble.Snapshot, 0)
+	for i := 0; i < numGames; i++ {
+		g := scrabble.NewGame(tileSet, dawg)
+		bot1 := scrabble.NewBot(scrabble.NewPlayer("Alphonse", g.Bag), &scrabble.OneOfNBest{N: 10})
+		bot2 := scrabble.NewBot(scrabble.NewPlayer("Sylvestre", g.Bag), &scrabble.OneOfNBest{N: 10})
+		g.Players[0], g.Players[1] = bot1.Player, bot2.Player
+		bots := [2]*scrabble.Bot{bot1, bot2}
+
+		for !g.IsOver() {
+			state := g.State()
+			snapshots = append(snapshots, scrabble.NewSnapshot(state))
+			move := bots[g.PlayerToMoveIndex()].GenerateMove(state)
+			if err := g.ApplyValid(move); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	return snapshots
+}
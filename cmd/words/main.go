@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"scrabble/pkg/scrabble"
+)
+
+var (
+	dictFile = flag.String("dict", "assets/defaultEN.txt", "Word list used to build the DAWG")
+	minLen   = flag.Int("min", 0, "Minimum length of the words")
+	maxLen   = flag.Int("max", 0, "Maximum length of the words, unbounded if zero")
+)
+
+const usage = `Usage: words [flags] query argument
+
+Queries:
+  anagram letters   words using all the letters, ? or * being a blank
+  sub letters       words using some of the letters, the longest first
+  contain pattern   words containing the pattern
+  match pattern     words matching the whole pattern
+  hooks word        letters that can be put in front of the word or after it
+  rank length       words of the length from the most probable to draw
+
+Patterns are made of letters, . for any letter and letter classes such
+as [aeiou], [a-m] or [^aeiou], each of which can be followed by ? when
+optional, * when repeated zero or more times, or + when repeated at
+least once.
+
+Flags:`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dict, err := scrabble.LoadDictionary(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dawg := scrabble.NewDawg(dict)
+
+	query, arg := strings.ToLower(flag.Arg(0)), strings.ToLower(flag.Arg(1))
+	var words []string
+	switch query {
+	case "anagram":
+		words = dawg.Anagrams(strings.ReplaceAll(arg, "?", "*"))
+	case "sub":
+		words = dawg.SubAnagrams(strings.ReplaceAll(arg, "?", "*"), maxInt(*minLen, 2))
+	case "contain":
+		words, err = dawg.Containing(arg)
+	case "match":
+		words, err = dawg.MatchPattern(arg, *minLen, *maxLen)
+	case "rank":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			log.Fatalf("invalid length %q", arg)
+		}
+		for _, r := range dawg.WordsByProbability(n, scrabble.DefaultTileSet) {
+			fmt.Printf("%6d %-15s %.3g\n", r.Rank, r.Word, r.Probability)
+		}
+		return
+	case "hooks":
+		front, back := dawg.Hooks(arg)
+		fmt.Printf("%s %s %s\n", string(front), arg, string(back))
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, word := range words {
+		length := len([]rune(word))
+		if length >= *minLen && (*maxLen == 0 || length <= *maxLen) {
+			fmt.Println(word)
+		}
+	}
+}
+
+func maxInt(i1, i2 int) int {
+	if i1 > i2 {
+		return i1
+	}
+	return i2
+}
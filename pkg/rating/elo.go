@@ -0,0 +1,76 @@
+package rating
+
+import "math"
+
+const (
+	// DefaultEloK is the K-factor of established players
+	DefaultEloK = 20
+	// DefaultEloProvisionalK is the K-factor of provisional players,
+	// larger so that their rating converges faster
+	DefaultEloProvisionalK = 40
+	// DefaultEloProvisionalGames is the number of games
+	// a player's Elo rating remains provisional
+	DefaultEloProvisionalGames = 20
+	// InitialRating is the rating of a new player
+	InitialRating = 1500
+)
+
+// Make sure Elo implements the Calculator interface
+var _ Calculator = (*Elo)(nil)
+
+// Elo is the Elo rating system: the rating moves by K times the
+// difference between the actual and the expected outcome of a game
+type Elo struct {
+	// K is the K-factor of established players;
+	// DefaultEloK is used if zero
+	K float64
+	// ProvisionalK is the K-factor of provisional players;
+	// DefaultEloProvisionalK is used if zero
+	ProvisionalK float64
+	// ProvisionalGames is the number of games a rating remains
+	// provisional; DefaultEloProvisionalGames is used if zero
+	ProvisionalGames int
+}
+
+// Initial returns the rating of a new player
+func (e *Elo) Initial() Rating {
+	return Rating{Value: InitialRating}
+}
+
+// Update returns the rating after the given games. The expected
+// outcomes are all computed from the rating before the games.
+func (e *Elo) Update(r Rating, results []Result) Rating {
+	k := e.K
+	if k == 0 {
+		k = DefaultEloK
+	}
+	if e.Provisional(r) {
+		k = e.ProvisionalK
+		if k == 0 {
+			k = DefaultEloProvisionalK
+		}
+	}
+	delta := 0.0
+	for _, result := range results {
+		delta += result.Score - EloExpected(r.Value, result.Opponent.Value)
+	}
+	r.Value += k * delta
+	r.Games += len(results)
+	return r
+}
+
+// Provisional returns true if the player has played fewer
+// than ProvisionalGames games
+func (e *Elo) Provisional(r Rating) bool {
+	games := e.ProvisionalGames
+	if games == 0 {
+		games = DefaultEloProvisionalGames
+	}
+	return r.Games < games
+}
+
+// EloExpected returns the expected outcome of a game
+// between players of the given Elo ratings
+func EloExpected(rating, oppRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (oppRating-rating)/400))
+}
@@ -0,0 +1,137 @@
+package rating
+
+import "math"
+
+const (
+	// DefaultGlickoDeviation is the rating deviation of a new player
+	DefaultGlickoDeviation = 350
+	// DefaultGlickoVolatility is the volatility of a new player
+	DefaultGlickoVolatility = 0.06
+	// DefaultGlickoTau constrains the change in volatility over time
+	DefaultGlickoTau = 0.5
+	// DefaultGlickoProvisionalDeviation is the deviation
+	// above which a Glicko-2 rating is provisional
+	DefaultGlickoProvisionalDeviation = 110
+
+	// glickoScale converts ratings to and from the Glicko-2 scale
+	glickoScale = 173.7178
+	// glickoEpsilon is the convergence tolerance of the volatility
+	glickoEpsilon = 0.000001
+)
+
+// Make sure Glicko2 implements the Calculator interface
+var _ Calculator = (*Glicko2)(nil)
+
+// Glicko2 is the Glicko-2 rating system, as described by Mark Glickman
+// in "Example of the Glicko-2 system". Besides the rating, it tracks
+// how reliable the rating is, with the rating deviation, and how
+// erratic the player is, with the volatility. The deviation grows over
+// the rating periods without games, and shrinks as games are played.
+type Glicko2 struct {
+	// Tau constrains the change in volatility over time;
+	// DefaultGlickoTau is used if zero
+	Tau float64
+	// ProvisionalDeviation is the deviation above which the rating is
+	// provisional; DefaultGlickoProvisionalDeviation is used if zero
+	ProvisionalDeviation float64
+}
+
+// Initial returns the rating of a new player
+func (gl *Glicko2) Initial() Rating {
+	return Rating{
+		Value:      InitialRating,
+		Deviation:  DefaultGlickoDeviation,
+		Volatility: DefaultGlickoVolatility,
+	}
+}
+
+// Update returns the rating at the end of a rating period in which the
+// given games were played. Without games, only the deviation increases.
+func (gl *Glicko2) Update(r Rating, results []Result) Rating {
+	mu := (r.Value - InitialRating) / glickoScale
+	phi := r.Deviation / glickoScale
+	if len(results) == 0 {
+		r.Deviation = math.Sqrt(phi*phi+r.Volatility*r.Volatility) * glickoScale
+		return r
+	}
+
+	// The estimated variance of the rating based on the game
+	// outcomes only, and the estimated improvement in rating
+	v, sum := 0.0, 0.0
+	for _, result := range results {
+		muOpp := (result.Opponent.Value - InitialRating) / glickoScale
+		g := glickoG(result.Opponent.Deviation / glickoScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muOpp)))
+		v += g * g * e * (1 - e)
+		sum += g * (result.Score - e)
+	}
+	v = 1 / v
+	delta := v * sum
+
+	sigma := gl.volatility(phi, r.Volatility, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * sum
+
+	return Rating{
+		Value:      mu*glickoScale + InitialRating,
+		Deviation:  phi * glickoScale,
+		Volatility: sigma,
+		Games:      r.Games + len(results),
+	}
+}
+
+// volatility returns the new volatility, found with the
+// Illinois algorithm as in step 5 of Glickman's paper
+func (gl *Glicko2) volatility(phi, sigma, v, delta float64) float64 {
+	tau := gl.Tau
+	if tau == 0 {
+		tau = DefaultGlickoTau
+	}
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	lo := a
+	var hi float64
+	if delta*delta > phi*phi+v {
+		hi = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		hi = a - k*tau
+	}
+	fLo, fHi := f(lo), f(hi)
+	for math.Abs(hi-lo) > glickoEpsilon {
+		c := lo + (lo-hi)*fLo/(fHi-fLo)
+		fC := f(c)
+		if fC*fHi <= 0 {
+			lo, fLo = hi, fHi
+		} else {
+			fLo /= 2
+		}
+		hi, fHi = c, fC
+	}
+	return math.Exp(lo / 2)
+}
+
+// Provisional returns true if the deviation is
+// above ProvisionalDeviation
+func (gl *Glicko2) Provisional(r Rating) bool {
+	deviation := gl.ProvisionalDeviation
+	if deviation == 0 {
+		deviation = DefaultGlickoProvisionalDeviation
+	}
+	return r.Deviation > deviation
+}
+
+// glickoG reduces the impact of a game
+// according to the opponent's deviation
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
@@ -0,0 +1,115 @@
+package rating
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"scrabble/pkg/scrabble"
+)
+
+// Entry is a rated game in the history of a player
+type Entry struct {
+	Time     time.Time
+	Opponent uuid.UUID
+	Score    float64
+	// Rating is the player's rating after the game
+	Rating Rating
+}
+
+// Ladder keeps the ratings of players, identified by their Player.ID,
+// and the history of their rated games. Each game is rated as soon as
+// it is recorded, as its own rating period. A Ladder is safe for
+// concurrent use.
+type Ladder struct {
+	Calculator Calculator
+
+	mu      sync.Mutex
+	ratings map[uuid.UUID]Rating
+	history map[uuid.UUID][]Entry
+}
+
+// NewLadder returns an empty Ladder rating players with the given Calculator
+func NewLadder(calculator Calculator) *Ladder {
+	return &Ladder{
+		Calculator: calculator,
+		ratings:    make(map[uuid.UUID]Rating),
+		history:    make(map[uuid.UUID][]Entry),
+	}
+}
+
+// Rating returns the current rating of a player,
+// the initial rating if they have not played yet
+func (l *Ladder) Rating(id uuid.UUID) Rating {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rating(id)
+}
+
+func (l *Ladder) rating(id uuid.UUID) Rating {
+	if r, ok := l.ratings[id]; ok {
+		return r
+	}
+	return l.Calculator.Initial()
+}
+
+// Provisional returns true if the rating of the player is provisional
+func (l *Ladder) Provisional(id uuid.UUID) bool {
+	return l.Calculator.Provisional(l.Rating(id))
+}
+
+// History returns the rated games of a player, from the oldest
+func (l *Ladder) History(id uuid.UUID) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Entry(nil), l.history[id]...)
+}
+
+// Record rates a game between two players, score being the outcome for
+// the first one, and returns their new ratings. Both ratings are
+// updated from the ratings the players had before the game.
+func (l *Ladder) Record(id, oppID uuid.UUID, score float64) (Rating, Rating, error) {
+	if score < Loss || score > Win {
+		return Rating{}, Rating{}, ErrInvalidScore
+	}
+	if id == oppID {
+		return Rating{}, Rating{}, ErrSamePlayer
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, opp := l.rating(id), l.rating(oppID)
+	r, opp = l.Calculator.Update(r, []Result{{Opponent: opp, Score: score}}),
+		l.Calculator.Update(opp, []Result{{Opponent: r, Score: Win - score}})
+
+	now := time.Now()
+	l.ratings[id], l.ratings[oppID] = r, opp
+	l.history[id] = append(l.history[id], Entry{Time: now, Opponent: oppID, Score: score, Rating: r})
+	l.history[oppID] = append(l.history[oppID], Entry{Time: now, Opponent: id, Score: Win - score, Rating: opp})
+	return r, opp, nil
+}
+
+// RecordGame rates a finished Game from the final scores of its players
+func (l *Ladder) RecordGame(g *scrabble.Game) error {
+	if !g.IsOver() {
+		return ErrGameNotOver
+	}
+	p, opp := g.Players[0], g.Players[1]
+	_, _, err := l.Record(p.ID, opp.ID, scoreFromPoints(p.Score, opp.Score))
+	return err
+}
+
+// Watch makes the Ladder rate the Game as soon as it is over,
+// after calling the Game's previous OnOver function, if any
+func (l *Ladder) Watch(g *scrabble.Game) {
+	previous := g.OnOver
+	g.OnOver = func(g *scrabble.Game) {
+		if previous != nil {
+			previous(g)
+		}
+		// The game is over and its players are distinct,
+		// so it cannot fail
+		_ = l.RecordGame(g)
+	}
+}
@@ -0,0 +1,64 @@
+// Package rating rates players from the outcome of their games, with
+// the Elo or the Glicko-2 rating system, and keeps their rating history.
+package rating
+
+import "errors"
+
+// Game outcomes, from the point of view of a player
+const (
+	Loss = 0.0
+	Draw = 0.5
+	Win  = 1.0
+)
+
+var (
+	ErrInvalidScore = errors.New("game outcome must be between 0 and 1")
+	ErrSamePlayer   = errors.New("a player cannot play against themselves")
+	ErrGameNotOver  = errors.New("game is not over")
+)
+
+// Rating is the strength of a player as estimated by a Calculator
+type Rating struct {
+	// Value is the rating itself, 1500 for a new player
+	Value float64
+	// Deviation is the uncertainty of the Value, which is 95% sure to
+	// be within two deviations of the true strength. Elo ratings
+	// have no deviation.
+	Deviation float64
+	// Volatility is the expected fluctuation of the strength of the
+	// player, used by Glicko-2 only
+	Volatility float64
+	// Games is the number of games rated so far
+	Games int
+}
+
+// Result is the outcome of a game against an opponent
+type Result struct {
+	Opponent Rating
+	// Score is Win, Draw or Loss
+	Score float64
+}
+
+// Calculator is a rating system
+type Calculator interface {
+	// Initial returns the rating of a new player
+	Initial() Rating
+	// Update returns the rating after the given games, played
+	// within one rating period
+	Update(r Rating, results []Result) Rating
+	// Provisional returns true if too little is known about the
+	// player for the rating to be reliable
+	Provisional(r Rating) bool
+}
+
+// scoreFromPoints returns the outcome of a game for the
+// player who scored the given points
+func scoreFromPoints(points, oppPoints int) float64 {
+	switch {
+	case points > oppPoints:
+		return Win
+	case points < oppPoints:
+		return Loss
+	}
+	return Draw
+}
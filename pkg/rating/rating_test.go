@@ -0,0 +1,101 @@
+package rating
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"scrabble/pkg/scrabble"
+)
+
+func assertClose(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("got %s %v, want %v", name, got, want)
+	}
+}
+
+// TestGlicko2Example checks the example of Glickman's paper
+func TestGlicko2Example(t *testing.T) {
+	gl := &Glicko2{Tau: 0.5}
+	r := gl.Update(Rating{Value: 1500, Deviation: 200, Volatility: 0.06}, []Result{
+		{Opponent: Rating{Value: 1400, Deviation: 30}, Score: Win},
+		{Opponent: Rating{Value: 1550, Deviation: 100}, Score: Loss},
+		{Opponent: Rating{Value: 1700, Deviation: 300}, Score: Loss},
+	})
+	assertClose(t, "rating", r.Value, 1464.06, 0.01)
+	assertClose(t, "deviation", r.Deviation, 151.52, 0.01)
+	assertClose(t, "volatility", r.Volatility, 0.05999, 0.00001)
+	if r.Games != 3 {
+		t.Errorf("got %d games, want 3", r.Games)
+	}
+
+	// Without games, only the deviation increases
+	idle := gl.Update(r, nil)
+	if idle.Value != r.Value || idle.Deviation <= r.Deviation {
+		t.Errorf("idle period gives %+v from %+v", idle, r)
+	}
+}
+
+func TestElo(t *testing.T) {
+	e := &Elo{}
+	r := e.Update(Rating{Value: 1600, Games: 30}, []Result{
+		{Opponent: Rating{Value: 1400}, Score: Loss},
+	})
+	// Expected outcome of 0.76, lost with a K-factor of 20
+	assertClose(t, "rating", r.Value, 1600-20*EloExpected(1600, 1400), 1e-9)
+	assertClose(t, "expected", EloExpected(1600, 1400), 0.7597, 0.0001)
+
+	// Provisional players move faster
+	p := e.Update(Rating{Value: 1600}, []Result{{Opponent: Rating{Value: 1400}, Score: Loss}})
+	assertClose(t, "provisional rating", 1600-p.Value, 2*(1600-r.Value), 1e-9)
+	if !e.Provisional(p) || e.Provisional(r) {
+		t.Error("only the player with few games should be provisional")
+	}
+}
+
+func TestLadderWatch(t *testing.T) {
+	dawg := scrabble.NewDawg(&scrabble.Dictionary{Words: []string{"at", "ta"}})
+	g := scrabble.NewGame(scrabble.DefaultTileSet, dawg)
+	g.Players[0] = scrabble.NewPlayer("A", g.Bag)
+	g.Players[1] = scrabble.NewPlayer("B", g.Bag)
+
+	ladder := NewLadder(&Glicko2{})
+	if err := ladder.RecordGame(g); err != ErrGameNotOver {
+		t.Fatalf("got error %v, want %v", err, ErrGameNotOver)
+	}
+	if _, _, err := ladder.Record(g.Players[0].ID, g.Players[0].ID, Win); err != ErrSamePlayer {
+		t.Fatalf("got error %v, want %v", err, ErrSamePlayer)
+	}
+	ladder.Watch(g)
+	for !g.IsOver() {
+		if err := g.ApplyValid(scrabble.NewPassMove()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, b := g.Players[0], g.Players[1]
+	for _, p := range []*scrabble.Player{a, b} {
+		history := ladder.History(p.ID)
+		if len(history) != 1 {
+			t.Fatalf("%s has %d rated games, want 1", p.Username, len(history))
+		}
+		if history[0].Rating != ladder.Rating(p.ID) {
+			t.Errorf("%s has rating %+v, but %+v in the history", p.Username, ladder.Rating(p.ID), history[0].Rating)
+		}
+		if !ladder.Provisional(p.ID) {
+			t.Errorf("%s should be provisional after one game", p.Username)
+		}
+	}
+	ra, rb := ladder.Rating(a.ID), ladder.Rating(b.ID)
+	switch {
+	case a.Score > b.Score && !(ra.Value > rb.Value),
+		a.Score < b.Score && !(ra.Value < rb.Value),
+		a.Score == b.Score && ra.Value != rb.Value:
+		t.Errorf("scores %d-%d give ratings %v and %v", a.Score, b.Score, ra.Value, rb.Value)
+	}
+	if ladder.Rating(uuid.New()) != ladder.Calculator.Initial() {
+		t.Error("new players should have the initial rating")
+	}
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"scrabble/pkg/scrabble"
+)
+
+// ANSI escape sequences
+const (
+	ansiReset    = "\x1b[0m"
+	ansiTile     = "\x1b[1;30;43m"
+	ansiBlank    = "\x1b[1;31;43m"
+	ansiLastMove = "\x1b[1;30;42m"
+	ansiDim      = "\x1b[2m"
+)
+
+var ansiSquares = [...]string{
+	Plain:        ansiDim,
+	DoubleLetter: "\x1b[30;46m",
+	TripleLetter: "\x1b[97;44m",
+	DoubleWord:   "\x1b[97;45m",
+	TripleWord:   "\x1b[97;41m",
+	Center:       "\x1b[97;45m",
+}
+
+// subscripts are the digits written below the line, for the tile values
+var subscripts = []rune("₀₁₂₃₄₅₆₇₈₉")
+
+// ANSI writes the board as text for a terminal, three characters per
+// square, colored with ANSI escape sequences unless opts.NoColor is
+// set. Empty premium squares show their label, e.g. TW for a triple
+// word, and blank tiles are in lowercase.
+func ANSI(w io.Writer, b *scrabble.Board, opts *Options) error {
+	opts = orDefault(opts)
+	var sb strings.Builder
+	if !opts.HideCoordinates {
+		sb.WriteString("   ")
+		for col := 0; col < scrabble.BoardSize; col++ {
+			sb.WriteString(" " + ColLabel(col) + " ")
+		}
+		sb.WriteString("\n")
+	}
+	for row := 0; row < scrabble.BoardSize; row++ {
+		if !opts.HideCoordinates {
+			sb.WriteString(fmt.Sprintf("%2s ", RowLabel(row)))
+		}
+		for col := 0; col < scrabble.BoardSize; col++ {
+			sb.WriteString(opts.ansiSquare(b.GetSquare(scrabble.Position{Row: row, Col: col})))
+		}
+		if !opts.HideCoordinates {
+			sb.WriteString(" " + RowLabel(row))
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ansiSquare returns the three characters of a square
+func (opts *Options) ansiSquare(sq *scrabble.Square) string {
+	var text, style string
+	if sq.Tile != nil {
+		text = opts.ansiTile(sq.Tile)
+		style = ansiTile
+		if sq.Tile.IsBlank() {
+			style = ansiBlank
+		}
+		if opts.highlighted(sq.Position) {
+			style = ansiLastMove
+		}
+	} else {
+		premium := SquarePremium(sq)
+		text = fmt.Sprintf("%-3s", labels[premium])
+		switch premium {
+		case Plain:
+			text = " . "
+		case Center:
+			text = " * "
+		}
+		style = ansiSquares[premium]
+	}
+	if opts.NoColor {
+		return text
+	}
+	return style + text + ansiReset
+}
+
+// ansiTile returns a tile as three characters: its letter and its value
+// as subscript digits. Blanks are in lowercase and have no value.
+func (opts *Options) ansiTile(t *scrabble.Tile) string {
+	letter := tileLetter(t)
+	if t.IsBlank() {
+		letter = strings.ToLower(letter)
+	}
+	text := []rune(" " + letter)
+	if !opts.HideValues && !t.IsBlank() {
+		text = text[1:]
+		for _, digit := range fmt.Sprint(t.Value) {
+			text = append(text, subscripts[digit-'0'])
+		}
+	}
+	for len(text) < 3 {
+		text = append(text, ' ')
+	}
+	return string(text)
+}
+
+// Rack writes the tiles of a rack as text for a terminal,
+// like the tiles of the board
+func Rack(w io.Writer, rack *scrabble.Rack, opts *Options) error {
+	opts = orDefault(opts)
+	tiles := make([]string, 0, len(rack.Tiles))
+	for _, t := range rack.Tiles {
+		tile := opts.ansiTile(t)
+		if !opts.NoColor {
+			tile = ansiTile + tile + ansiReset
+		}
+		tiles = append(tiles, tile)
+	}
+	_, err := io.WriteString(w, strings.Join(tiles, " "))
+	return err
+}
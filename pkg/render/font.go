@@ -0,0 +1,354 @@
+package render
+
+// Size of the glyphs of the bitmap font, in dots
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// glyphs is a 5x7 bitmap font of the characters drawn on boards:
+// uppercase letters, digits and a few symbols
+var glyphs = map[rune][glyphHeight]string{
+	'A': {
+		".###.",
+		"#...#",
+		"#...#",
+		"#####",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'B': {
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+	},
+	'C': {
+		".###.",
+		"#...#",
+		"#....",
+		"#....",
+		"#....",
+		"#...#",
+		".###.",
+	},
+	'D': {
+		"####.",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"####.",
+	},
+	'E': {
+		"#####",
+		"#....",
+		"#....",
+		"####.",
+		"#....",
+		"#....",
+		"#####",
+	},
+	'F': {
+		"#####",
+		"#....",
+		"#....",
+		"####.",
+		"#....",
+		"#....",
+		"#....",
+	},
+	'G': {
+		".###.",
+		"#...#",
+		"#....",
+		"#.###",
+		"#...#",
+		"#...#",
+		".####",
+	},
+	'H': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#####",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'I': {
+		".###.",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'J': {
+		"..###",
+		"...#.",
+		"...#.",
+		"...#.",
+		"...#.",
+		"#..#.",
+		".##..",
+	},
+	'K': {
+		"#...#",
+		"#..#.",
+		"#.#..",
+		"##...",
+		"#.#..",
+		"#..#.",
+		"#...#",
+	},
+	'L': {
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#####",
+	},
+	'M': {
+		"#...#",
+		"##.##",
+		"#.#.#",
+		"#.#.#",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'N': {
+		"#...#",
+		"#...#",
+		"##..#",
+		"#.#.#",
+		"#..##",
+		"#...#",
+		"#...#",
+	},
+	'O': {
+		".###.",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'P': {
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+		"#....",
+		"#....",
+		"#....",
+	},
+	'Q': {
+		".###.",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#.#.#",
+		"#..#.",
+		".##.#",
+	},
+	'R': {
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+		"#.#..",
+		"#..#.",
+		"#...#",
+	},
+	'S': {
+		".####",
+		"#....",
+		"#....",
+		".###.",
+		"....#",
+		"....#",
+		"####.",
+	},
+	'T': {
+		"#####",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+	},
+	'U': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'V': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+	},
+	'W': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#.#.#",
+		"#.#.#",
+		"#.#.#",
+		".#.#.",
+	},
+	'X': {
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+		".#.#.",
+		"#...#",
+		"#...#",
+	},
+	'Y': {
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+	},
+	'Z': {
+		"#####",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		"#....",
+		"#####",
+	},
+	'0': {
+		".###.",
+		"#...#",
+		"#..##",
+		"#.#.#",
+		"##..#",
+		"#...#",
+		".###.",
+	},
+	'1': {
+		"..#..",
+		".##..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'2': {
+		".###.",
+		"#...#",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		"#####",
+	},
+	'3': {
+		"#####",
+		"...#.",
+		"..#..",
+		"...#.",
+		"....#",
+		"#...#",
+		".###.",
+	},
+	'4': {
+		"...#.",
+		"..##.",
+		".#.#.",
+		"#..#.",
+		"#####",
+		"...#.",
+		"...#.",
+	},
+	'5': {
+		"#####",
+		"#....",
+		"####.",
+		"....#",
+		"....#",
+		"#...#",
+		".###.",
+	},
+	'6': {
+		"..##.",
+		".#...",
+		"#....",
+		"####.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'7': {
+		"#####",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		".#...",
+		".#...",
+	},
+	'8': {
+		".###.",
+		"#...#",
+		"#...#",
+		".###.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'9': {
+		".###.",
+		"#...#",
+		"#...#",
+		".####",
+		"....#",
+		"...#.",
+		".##..",
+	},
+	'*': {
+		".....",
+		"..#..",
+		"#.#.#",
+		".###.",
+		"#.#.#",
+		"..#..",
+		".....",
+	},
+	'?': {
+		".###.",
+		"#...#",
+		"....#",
+		"...#.",
+		"..#..",
+		".....",
+		"..#..",
+	},
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"scrabble/pkg/scrabble"
+)
+
+// PNG writes the board as a PNG image, see Image
+func PNG(w io.Writer, b *scrabble.Board, opts *Options) error {
+	return png.Encode(w, Image(b, opts))
+}
+
+// Image draws the board like SVG does, with the built-in bitmap font
+// so that it needs no font files
+func Image(b *scrabble.Board, opts *Options) *image.RGBA {
+	opts = orDefault(opts)
+	size, margin := opts.squareSize(), opts.margin()
+	width := margin + scrabble.BoardSize*size + 1
+
+	img := image.NewRGBA(image.Rect(0, 0, width, width))
+	fill(img, img.Bounds(), colorBackground)
+	fill(img, image.Rect(margin, margin, width, width), colorGrid)
+
+	if margin > 0 {
+		scale := fontScale(margin / 2)
+		for i := 0; i < scrabble.BoardSize; i++ {
+			center := margin + i*size + size/2
+			drawText(img, ColLabel(i), center, margin/2, scale, colorCoordinate)
+			drawText(img, RowLabel(i), margin/2, center, scale, colorCoordinate)
+		}
+	}
+
+	for row := 0; row < scrabble.BoardSize; row++ {
+		for col := 0; col < scrabble.BoardSize; col++ {
+			sq := b.GetSquare(scrabble.Position{Row: row, Col: col})
+			x, y := margin+col*size, margin+row*size
+			opts.drawSquare(img, sq, x, y, size)
+		}
+	}
+	return img
+}
+
+// drawSquare draws a square of the given size at x, y
+func (opts *Options) drawSquare(img *image.RGBA, sq *scrabble.Square, x, y, size int) {
+	premium := SquarePremium(sq)
+	c := colorSquares[premium]
+	if sq.Tile != nil {
+		c = colorTile
+		if opts.highlighted(sq.Position) {
+			c = colorLastMove
+		}
+	}
+	fill(img, image.Rect(x+1, y+1, x+size, y+size), c)
+
+	cx, cy := x+size/2, y+size/2
+	if sq.Tile == nil {
+		drawText(img, labels[premium], cx, cy, fontScale(size*3/10), colorLabels[premium])
+		return
+	}
+
+	letterColor := colorLetter
+	if sq.Tile.IsBlank() {
+		letterColor = colorBlank
+	}
+	drawText(img, tileLetter(sq.Tile), cx, cy, fontScale(size*6/10), letterColor)
+	if !opts.HideValues && !sq.Tile.IsBlank() {
+		value := fmt.Sprint(sq.Tile.Value)
+		scale := fontScale(size / 5)
+		// Right align the value in the bottom right corner
+		w := textWidth(value, scale)
+		drawText(img, value, x+size-2-w/2, y+size-2-glyphHeight*scale/2, scale, colorLetter)
+	}
+}
+
+// fill paints a rectangle of the image with a color
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// fontScale returns the scale of the bitmap font giving
+// letters of about the given height in pixels
+func fontScale(height int) int {
+	if scale := height / glyphHeight; scale > 1 {
+		return scale
+	}
+	return 1
+}
+
+// textWidth returns the width of a text in pixels
+func textWidth(text string, scale int) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return (n*(glyphWidth+1) - 1) * scale
+}
+
+// drawText draws a text centered on cx, cy with the bitmap font
+// magnified by the given scale. Unknown characters are left blank.
+func drawText(img *image.RGBA, text string, cx, cy, scale int, c color.RGBA) {
+	x := cx - textWidth(text, scale)/2
+	y := cy - glyphHeight*scale/2
+	for _, r := range text {
+		if glyph, ok := glyphs[r]; ok {
+			for gy, line := range glyph {
+				for gx, dot := range line {
+					if dot == '#' {
+						px, py := x+gx*scale, y+gy*scale
+						fill(img, image.Rect(px, py, px+scale, py+scale), c)
+					}
+				}
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
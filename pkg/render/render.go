@@ -0,0 +1,148 @@
+// Package render draws Scrabble boards for terminals, with ANSI
+// escape sequences, for the web, as SVG, and as PNG images.
+package render
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"scrabble/pkg/scrabble"
+)
+
+// DefaultSquareSize is the size of a square in pixels, in SVG and PNG
+const DefaultSquareSize = 40
+
+// Options customize the rendering of a board. The zero value renders
+// tile values and coordinates, in color, without highlighting.
+type Options struct {
+	// LastMove holds the squares to highlight, usually the
+	// covers of the last tile move
+	LastMove scrabble.Covers
+	// HideValues leaves out the values of the tiles
+	HideValues bool
+	// HideCoordinates leaves out the row numbers and column letters
+	HideCoordinates bool
+	// NoColor renders ANSI text without escape sequences
+	NoColor bool
+	// SquareSize is the size of a square in pixels, in SVG and PNG;
+	// DefaultSquareSize is used if zero
+	SquareSize int
+}
+
+// Premium is the kind of a square
+type Premium int
+
+const (
+	Plain Premium = iota
+	DoubleLetter
+	TripleLetter
+	DoubleWord
+	TripleWord
+	// Center is the starting square, a double word
+	Center
+)
+
+// labels are the texts of the empty squares
+var labels = [...]string{
+	Plain:        "",
+	DoubleLetter: "DL",
+	TripleLetter: "TL",
+	DoubleWord:   "DW",
+	TripleWord:   "TW",
+	Center:       "*",
+}
+
+// Colors of the SVG and PNG renderings
+var (
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorGrid       = color.RGBA{R: 0x2f, G: 0x4f, B: 0x4f, A: 0xff}
+	colorCoordinate = color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+	colorTile       = color.RGBA{R: 0xf2, G: 0xc4, B: 0x6d, A: 0xff}
+	colorLastMove   = color.RGBA{R: 0xb5, G: 0xe4, B: 0x8c, A: 0xff}
+	colorLetter     = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	colorBlank      = color.RGBA{R: 0xc0, G: 0x39, B: 0x2b, A: 0xff}
+
+	colorSquares = [...]color.RGBA{
+		Plain:        {R: 0xe8, G: 0xe2, B: 0xcc, A: 0xff},
+		DoubleLetter: {R: 0xa8, G: 0xd8, B: 0xea, A: 0xff},
+		TripleLetter: {R: 0x3a, G: 0x7f, B: 0xc1, A: 0xff},
+		DoubleWord:   {R: 0xf4, G: 0xb6, B: 0xc2, A: 0xff},
+		TripleWord:   {R: 0xd9, G: 0x53, B: 0x4f, A: 0xff},
+		Center:       {R: 0xf4, G: 0xb6, B: 0xc2, A: 0xff},
+	}
+	colorLabels = [...]color.RGBA{
+		Plain:        colorLetter,
+		DoubleLetter: colorLetter,
+		TripleLetter: colorBackground,
+		DoubleWord:   colorLetter,
+		TripleWord:   colorBackground,
+		Center:       colorLetter,
+	}
+)
+
+// SquarePremium returns the kind of a square
+func SquarePremium(sq *scrabble.Square) Premium {
+	switch {
+	case sq.Position.Row == scrabble.BoardCenter && sq.Position.Col == scrabble.BoardCenter:
+		return Center
+	case sq.WordMultiplier == 3:
+		return TripleWord
+	case sq.WordMultiplier == 2:
+		return DoubleWord
+	case sq.LetterMultiplier == 3:
+		return TripleLetter
+	case sq.LetterMultiplier == 2:
+		return DoubleLetter
+	}
+	return Plain
+}
+
+// RowLabel returns the label of a row, numbered from 1
+func RowLabel(row int) string {
+	return strconv.Itoa(row + 1)
+}
+
+// ColLabel returns the label of a column, a letter from A
+func ColLabel(col int) string {
+	return string(rune('A' + col))
+}
+
+// tileLetter returns the letter of a tile in uppercase, whether
+// it is a blank or not, or ? for a blank still on a rack
+func tileLetter(t *scrabble.Tile) string {
+	if t.Letter == '*' {
+		return "?"
+	}
+	return strings.ToUpper(string(t.Letter))
+}
+
+// highlighted returns true if the square is part of the last move
+func (opts *Options) highlighted(pos scrabble.Position) bool {
+	_, ok := opts.LastMove[pos]
+	return ok
+}
+
+// squareSize returns the size of a square in pixels
+func (opts *Options) squareSize() int {
+	if opts.SquareSize > 0 {
+		return opts.SquareSize
+	}
+	return DefaultSquareSize
+}
+
+// margin returns the size of the margin holding the coordinates
+func (opts *Options) margin() int {
+	if opts.HideCoordinates {
+		return 0
+	}
+	return opts.squareSize() * 3 / 4
+}
+
+// orDefault returns the options, or the zero options if nil
+func orDefault(opts *Options) *Options {
+	if opts == nil {
+		return &Options{}
+	}
+	return opts
+}
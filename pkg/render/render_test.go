@@ -0,0 +1,128 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"scrabble/pkg/scrabble"
+)
+
+// testBoard returns a board holding "quiz", played with a blank i,
+// and "zap" down from its z, which is the last move
+func testBoard(t *testing.T) (*scrabble.Board, scrabble.Covers) {
+	t.Helper()
+	b := scrabble.NewBoard()
+	last := scrabble.Covers{
+		{Row: 8, Col: 9}: {Letter: 'a', Actual: 'a'},
+		{Row: 9, Col: 9}: {Letter: 'p', Actual: 'p'},
+	}
+	for _, covers := range []scrabble.Covers{
+		{
+			{Row: 7, Col: 6}: {Letter: 'q', Actual: 'q'},
+			{Row: 7, Col: 7}: {Letter: 'u', Actual: 'u'},
+			{Row: 7, Col: 8}: {Letter: '*', Actual: 'i'},
+			{Row: 7, Col: 9}: {Letter: 'z', Actual: 'z'},
+		},
+		last,
+	} {
+		if err := b.PlaceCovers(covers, scrabble.DefaultTileSet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b, last
+}
+
+func TestANSI(t *testing.T) {
+	b, last := testBoard(t)
+	var buf bytes.Buffer
+	if err := ANSI(&buf, b, &Options{NoColor: true, LastMove: last}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != scrabble.BoardSize+2 {
+		t.Fatalf("got %d lines, want %d", len(lines), scrabble.BoardSize+2)
+	}
+	for _, want := range []struct {
+		line int
+		text string
+	}{
+		{0, " A  B  C "},
+		{1, " 1 TW  .  . DL "},
+		// The blank i has no value and is in lowercase
+		{8, " Q₈ U₁  i Z₁₀ . DL "},
+		{15, "15 TW "},
+	} {
+		if !strings.Contains(lines[want.line], want.text) {
+			t.Errorf("line %d is %q, want it to contain %q", want.line, lines[want.line], want.text)
+		}
+	}
+
+	buf.Reset()
+	ANSI(&buf, b, &Options{LastMove: last})
+	if !strings.Contains(buf.String(), ansiLastMove+"A₁ "+ansiReset) {
+		t.Error("the last move is not highlighted")
+	}
+}
+
+func TestSVG(t *testing.T) {
+	b, last := testBoard(t)
+	var buf bytes.Buffer
+	if err := SVG(&buf, b, &Options{LastMove: last}); err != nil {
+		t.Fatal(err)
+	}
+	// The SVG must be well formed, with a rect per square
+	// besides the background and the grid
+	rects := 0
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "rect" {
+			rects++
+		}
+	}
+	if want := scrabble.BoardSize*scrabble.BoardSize + 2; rects != want {
+		t.Errorf("got %d rects, want %d", rects, want)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	b, last := testBoard(t)
+	opts := &Options{LastMove: last, SquareSize: 20}
+	var buf bytes.Buffer
+	if err := PNG(&buf, b, opts); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := opts.margin() + scrabble.BoardSize*20 + 1; img.Bounds().Dx() != want || img.Bounds().Dy() != want {
+		t.Fatalf("got size %v, want %d", img.Bounds().Size(), want)
+	}
+	// The top left corners of the squares have their
+	// colors, the letters being drawn in their middle
+	for _, tt := range []struct {
+		pos  scrabble.Position
+		want color.Color
+	}{
+		{scrabble.Position{Row: 0, Col: 0}, colorSquares[TripleWord]},
+		{scrabble.Position{Row: 7, Col: 6}, colorTile},
+		{scrabble.Position{Row: 9, Col: 9}, colorLastMove},
+	} {
+		x, y := opts.margin()+tt.pos.Col*20+2, opts.margin()+tt.pos.Row*20+2
+		if got := img.At(x, y); got != tt.want {
+			t.Errorf("square %v has color %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"scrabble/pkg/scrabble"
+)
+
+// SVG writes the board as an SVG image, with the premium squares,
+// the tiles and their values, blank tiles having a red letter and no
+// value, and the coordinates around the board
+func SVG(w io.Writer, b *scrabble.Board, opts *Options) error {
+	opts = orDefault(opts)
+	size, margin := opts.squareSize(), opts.margin()
+	width := margin + scrabble.BoardSize*size + 1
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" text-anchor="middle" dominant-baseline="central">`+"\n",
+		width, width, width, width)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, width, hex(colorBackground))
+	fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		margin, margin, scrabble.BoardSize*size+1, scrabble.BoardSize*size+1, hex(colorGrid))
+
+	if margin > 0 {
+		for i := 0; i < scrabble.BoardSize; i++ {
+			center := margin + i*size + size/2
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+				center, margin/2, margin/2, hex(colorCoordinate), ColLabel(i))
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+				margin/2, center, margin/2, hex(colorCoordinate), RowLabel(i))
+		}
+	}
+
+	for row := 0; row < scrabble.BoardSize; row++ {
+		for col := 0; col < scrabble.BoardSize; col++ {
+			sq := b.GetSquare(scrabble.Position{Row: row, Col: col})
+			x, y := margin+col*size, margin+row*size
+			opts.svgSquare(&sb, sq, x, y, size)
+		}
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// svgSquare writes a square of the given size at x, y
+func (opts *Options) svgSquare(sb *strings.Builder, sq *scrabble.Square, x, y, size int) {
+	premium := SquarePremium(sq)
+	fill := colorSquares[premium]
+	if sq.Tile != nil {
+		fill = colorTile
+		if opts.highlighted(sq.Position) {
+			fill = colorLastMove
+		}
+	}
+	fmt.Fprintf(sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		x+1, y+1, size-1, size-1, hex(fill))
+
+	cx, cy := x+size/2, y+size/2
+	if sq.Tile == nil {
+		if label := labels[premium]; label != "" {
+			fmt.Fprintf(sb, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+				cx, cy, size*3/10, hex(colorLabels[premium]), label)
+		}
+		return
+	}
+
+	letterColor := colorLetter
+	if sq.Tile.IsBlank() {
+		letterColor = colorBlank
+	}
+	fmt.Fprintf(sb, `<text x="%d" y="%d" font-size="%d" font-weight="bold" fill="%s">%s</text>`+"\n",
+		cx, cy, size*6/10, hex(letterColor), tileLetter(sq.Tile))
+	if !opts.HideValues && !sq.Tile.IsBlank() {
+		fmt.Fprintf(sb, `<text x="%d" y="%d" font-size="%d" text-anchor="end" fill="%s">%d</text>`+"\n",
+			x+size-2, y+size*4/5, size/4, hex(colorLetter), sq.Tile.Value)
+	}
+}
+
+// hex returns a color in the #rrggbb notation
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
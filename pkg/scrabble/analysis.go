@@ -0,0 +1,240 @@
+package scrabble
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultAccuracyTolerance is the equity a move may lose and
+// still count as the best move, in the accuracy of a player
+const DefaultAccuracyTolerance = 0.5
+
+// Analyzer replays the MoveList of a Game and compares each move with
+// the moves that could have been played instead, as ranked by a static
+// evaluation. The best move by equity is the one StaticEval would have
+// picked, so the analysis does not account for simulations or endgame
+// search.
+type Analyzer struct {
+	// Static ranks the moves; a StaticEval with default
+	// leaves is used if nil
+	Static *StaticEval
+	// Tolerance is the equity a move may lose and still count as the
+	// best move; DefaultAccuracyTolerance is used if zero
+	Tolerance float64
+}
+
+// MoveAnalysis compares a move with the best moves in its position
+type MoveAnalysis struct {
+	// Turn is the index of the move in the MoveList
+	Turn   int    `json:"turn"`
+	Player string `json:"player"`
+	Rack   string `json:"rack"`
+	// Played is the move in the usual notation, see TileMove.Notation,
+	// or an exchange or a pass
+	Played string  `json:"played"`
+	Score  int     `json:"score"`
+	Equity float64 `json:"equity"`
+	// Best is the move with the highest equity
+	Best       string  `json:"best"`
+	BestScore  int     `json:"bestScore"`
+	BestEquity float64 `json:"bestEquity"`
+	// TopScore is the score of the highest scoring move
+	TopScore int `json:"topScore"`
+	// EquityLost and PointsLost are the equity lost compared to the
+	// best move, and the points lost compared to the highest scoring
+	// move, zero if the played move is at least as good
+	EquityLost float64 `json:"equityLost"`
+	PointsLost int     `json:"pointsLost"`
+	// IsBest is true if the move lost no more than the tolerance
+	IsBest bool `json:"isBest"`
+	Bingo  bool `json:"bingo"`
+	// MissedBingo is the highest scoring bingo, if one could be played
+	// and the move is not a bingo
+	MissedBingo      string `json:"missedBingo,omitempty"`
+	MissedBingoScore int    `json:"missedBingoScore,omitempty"`
+}
+
+// PlayerAnalysis sums up the moves of a player
+type PlayerAnalysis struct {
+	Name         string  `json:"name"`
+	Score        int     `json:"score"`
+	Moves        int     `json:"moves"`
+	BestMoves    int     `json:"bestMoves"`
+	EquityLost   float64 `json:"equityLost"`
+	PointsLost   int     `json:"pointsLost"`
+	Bingos       int     `json:"bingos"`
+	MissedBingos int     `json:"missedBingos"`
+	// Accuracy is the percentage of moves that were the best move,
+	// within the tolerance of the Analyzer
+	Accuracy float64 `json:"accuracy"`
+}
+
+// GameAnalysis is the analysis of every move of a Game
+type GameAnalysis struct {
+	Players [2]*PlayerAnalysis `json:"players"`
+	Moves   []*MoveAnalysis    `json:"moves"`
+}
+
+// Analyze replays the moves of a Game on a new board, and compares
+// each of them with the moves that could have been played with the
+// same rack. The final rack adjustments are not analyzed.
+func (a *Analyzer) Analyze(g *Game) *GameAnalysis {
+	static := a.Static
+	if static == nil {
+		static = &StaticEval{}
+	}
+	tolerance := a.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultAccuracyTolerance
+	}
+
+	analysis := &GameAnalysis{Moves: make([]*MoveAnalysis, 0, len(g.MoveList))}
+	for i, p := range g.Players {
+		analysis.Players[i] = &PlayerAnalysis{Name: p.Username, Score: p.Score}
+	}
+
+	board := NewBoard()
+	boardState := NewBoardState(board, g.DAWG)
+	bagCount := -2 * RackSize
+	for _, count := range g.TileSet.Count {
+		bagCount += count
+	}
+	var scores [2]int
+
+	for turn, item := range g.MoveList {
+		if _, ok := item.Move.(*FinalMove); ok {
+			continue
+		}
+		index := turn % 2
+		state := &GameState{
+			DAWG:            g.DAWG,
+			TileSet:         g.TileSet,
+			Board:           board,
+			Rack:            newRackOf(item.RackBefore, g.TileSet),
+			ExchangeAllowed: bagCount >= RackSize,
+			BagTileCount:    bagCount,
+			BoardState:      boardState,
+			Spread:          scores[index] - scores[1-index],
+		}
+		ma := a.analyzeMove(static, state, item.Move)
+		ma.Turn = turn
+		ma.Player = analysis.Players[index].Name
+		ma.IsBest = ma.EquityLost <= tolerance
+		analysis.Moves = append(analysis.Moves, ma)
+
+		pa := analysis.Players[index]
+		pa.Moves++
+		pa.EquityLost += ma.EquityLost
+		pa.PointsLost += ma.PointsLost
+		if ma.IsBest {
+			pa.BestMoves++
+		}
+		if ma.Bingo {
+			pa.Bingos++
+		}
+		if ma.MissedBingo != "" {
+			pa.MissedBingos++
+		}
+
+		// Play the move on the board
+		scores[index] += ma.Score
+		if tileMove, ok := item.Move.(*TileMove); ok {
+			// Should not fail, the move having been played
+			_ = boardState.PlaceCovers(tileMove.Covers, g.TileSet)
+			bagCount -= minInt(len(tileMove.Covers), bagCount)
+		}
+	}
+
+	for _, pa := range analysis.Players {
+		if pa.Moves > 0 {
+			pa.Accuracy = 100 * float64(pa.BestMoves) / float64(pa.Moves)
+		}
+	}
+	return analysis
+}
+
+// analyzeMove compares a move with the moves that could have been
+// played in the given state, before the move is played
+func (a *Analyzer) analyzeMove(static *StaticEval, state *GameState, played Move) *MoveAnalysis {
+	ma := &MoveAnalysis{
+		Rack:   state.Rack.AsString(),
+		Played: describeMove(state.Board, played),
+		Score:  played.Score(state),
+		Equity: static.Equity(state, played),
+	}
+	if tileMove, ok := played.(*TileMove); ok {
+		ma.Bingo = len(tileMove.Covers) == RackSize
+	}
+
+	moves := state.GenerateMoves()
+	candidates := static.Evaluate(state, moves)
+	if len(candidates) == 0 {
+		// Passing was the only option
+		ma.Best, ma.BestEquity = ma.Played, ma.Equity
+		return ma
+	}
+	best := candidates[0]
+	ma.Best = describeMove(state.Board, best.Move)
+	ma.BestScore = best.Move.Score(state)
+	ma.BestEquity = best.Equity
+	if lost := best.Equity - ma.Equity; lost > 0 {
+		ma.EquityLost = lost
+	}
+
+	var bingo *TileMove
+	for _, move := range moves {
+		score := move.Score(state)
+		ma.TopScore = maxInt(ma.TopScore, score)
+		if tileMove := move.(*TileMove); len(tileMove.Covers) == RackSize &&
+			(bingo == nil || score > bingo.Score(state)) {
+			bingo = tileMove
+		}
+	}
+	ma.PointsLost = maxInt(0, ma.TopScore-ma.Score)
+	if bingo != nil && !ma.Bingo {
+		ma.MissedBingo = bingo.Notation(state.Board)
+		ma.MissedBingoScore = bingo.Score(state)
+	}
+	return ma
+}
+
+// describeMove returns a move in the usual notation, on the Board
+// it is about to be applied to
+func describeMove(b *Board, move Move) string {
+	switch m := move.(type) {
+	case *TileMove:
+		return m.Notation(b)
+	case *ExchangeMove:
+		return "exchange " + m.Letters
+	case *PassMove:
+		return "pass"
+	}
+	return move.String()
+}
+
+// WriteReport writes the analysis in a human readable form: a line
+// per move, marking the moves that lost equity with the best move,
+// then the totals of each player
+func (ga *GameAnalysis) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+	for _, ma := range ga.Moves {
+		sb.WriteString(fmt.Sprintf("%3d %-12s %-8s %-22s %4d", ma.Turn+1, ma.Player, ma.Rack, ma.Played, ma.Score))
+		if !ma.IsBest {
+			sb.WriteString(fmt.Sprintf("   best: %s %d (-%.1f)", ma.Best, ma.BestScore, ma.EquityLost))
+		}
+		if ma.MissedBingo != "" {
+			sb.WriteString(fmt.Sprintf("   missed bingo: %s %d", ma.MissedBingo, ma.MissedBingoScore))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	for _, pa := range ga.Players {
+		sb.WriteString(fmt.Sprintf("%s: %d points, accuracy %.1f%% (%d of %d moves), "+
+			"%.1f equity and %d points lost, %d bingos, %d missed\n",
+			pa.Name, pa.Score, pa.Accuracy, pa.BestMoves, pa.Moves,
+			pa.EquityLost, pa.PointsLost, pa.Bingos, pa.MissedBingos))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
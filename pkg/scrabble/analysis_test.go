@@ -0,0 +1,44 @@
+package scrabble
+
+import "testing"
+
+func TestAnalyzer(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+	g.Players[0].Rack = newRackOf("cabstex", g.TileSet)
+	static := &StaticEval{}
+	best := static.Evaluate(g.State(), g.State().GenerateMoves())[0]
+
+	// A plays a weak move, then B passes
+	move, err := ParseTileMove(g.Board, "8H", "ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, move := range []Move{move, NewPassMove()} {
+		if err := g.ApplyValid(move); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analysis := (&Analyzer{Static: static}).Analyze(g)
+	if len(analysis.Moves) != 2 {
+		t.Fatalf("got %d analyzed moves, want 2", len(analysis.Moves))
+	}
+	ab := analysis.Moves[0]
+	if ab.Played != "8H ab" || ab.Rack != "cabstex" || ab.IsBest {
+		t.Errorf("got %+v for 8H ab", ab)
+	}
+	if want := best.Move.(*TileMove).Notation(NewBoard()); ab.Best != want {
+		t.Errorf("got best move %s, want %s", ab.Best, want)
+	}
+	if ab.EquityLost <= 0 || ab.PointsLost <= 0 {
+		t.Errorf("8H ab should lose equity and points: %+v", ab)
+	}
+	if pass := analysis.Moves[1]; pass.Played != "pass" || pass.Player != "B" {
+		t.Errorf("got %+v for the pass", pass)
+	}
+	if a := analysis.Players[0]; a.Moves != 1 || a.Accuracy != 0 {
+		t.Errorf("got %+v for A", a)
+	}
+}
@@ -2,18 +2,31 @@ package scrabble
 
 import (
 	"strings"
-
-	"golang.org/x/exp/slices"
+	"unicode"
 )
 
 type Axis struct {
-	state             *GameState
-	horizontal        bool
-	rack              []rune
-	rackString        string
-	squares           [BoardSize]*Square
-	crossCheckLetters [BoardSize][]rune
-	isAnchor          [BoardSize]bool
+	state      *GameState
+	horizontal bool
+	rack       []rune
+	rackString string
+	squares    [BoardSize]*Square
+	// crossChecks holds the letters that can be placed on each
+	// square, given both the cross checks and the rack
+	crossChecks [BoardSize]LetterSet
+	isAnchor    [BoardSize]bool
+	// done, when not nil, stops the move generation once closed
+	done <-chan struct{}
+	// emit, when not nil, receives the moves as soon as they are found
+	// instead of collecting them
+	emit func(Move)
+	// topK, when not zero, keeps only the topK highest
+	// scoring moves in best instead of collecting them all
+	topK            int
+	best            moveHeap
+	crossScores     [BoardSize]int
+	hasCrossing     [BoardSize]bool
+	crossScoreKnown [BoardSize]bool
 }
 
 func (a *Axis) Init(gs *GameState, index int, horizontal bool) {
@@ -21,6 +34,8 @@ func (a *Axis) Init(gs *GameState, index int, horizontal bool) {
 	a.horizontal = horizontal
 	a.rack = gs.Rack.AsRunes()
 	a.rackString = gs.Rack.AsString()
+	rackSet := letterSetOf(a.rackString)
+	hasBlank := strings.ContainsRune(a.rackString, '*')
 	b := gs.Board
 	// Build an array of pointers to the squares on this axis
 	for i := 0; i < BoardSize; i++ {
@@ -41,7 +56,9 @@ func (a *Axis) Init(gs *GameState, index int, horizontal bool) {
 		}
 
 		var isAnchor bool
-		if b.Squares[BoardCenter][BoardCenter].Tile == nil {
+		if bs := gs.BoardState; bs != nil {
+			isAnchor = bs.IsAnchor(s.Position)
+		} else if b.Squares[BoardCenter][BoardCenter].Tile == nil {
 			// If no tile has yet been placed on the board,
 			// mark the center square of the center column as an anchor
 			isAnchor = (index == BoardCenter) && (i == BoardCenter)
@@ -50,13 +67,13 @@ func (a *Axis) Init(gs *GameState, index int, horizontal bool) {
 		}
 
 		if !isAnchor {
-			if strings.ContainsRune(a.rackString, '*') {
-				a.crossCheckLetters[i] = []rune{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z'}
+			if hasBlank {
+				a.crossChecks[i] = AllLetters
 				continue
 			}
 			// Empty square with no adjacent tiles: not an anchor,
 			// and we can place any letter from the rack here
-			a.crossCheckLetters[i] = a.rack
+			a.crossChecks[i] = rackSet
 		} else {
 			// This is an anchor square, i.e. an empty square with
 			// at least one adjacent tile. Playable letters are the ones
@@ -66,36 +83,32 @@ func (a *Axis) Init(gs *GameState, index int, horizontal bool) {
 			// cross-words.
 			a.isAnchor[i] = true
 
-			var crossCheckLetters []rune
 			if len(a.rack) != 0 {
 				playable := a.CrossCheck(s)
-				if strings.ContainsRune(a.rackString, '*') {
-					crossCheckLetters = playable
-				} else {
-					for _, l := range playable {
-						if !strings.ContainsRune(a.rackString, l) {
-							continue
-						}
-
-						crossCheckLetters = append(crossCheckLetters, l)
-					}
+				if !hasBlank {
+					playable &= rackSet
 				}
+				a.crossChecks[i] = playable
 			}
-
-			a.crossCheckLetters[i] = crossCheckLetters
 		}
 	}
 }
 
-func (a *Axis) CrossCheck(s *Square) []rune {
+// CrossCheck returns the set of letters allowed on the square by
+// the cross word(s), using the incrementally maintained BoardState
+// if there is one
+func (a *Axis) CrossCheck(s *Square) LetterSet {
+	if bs := a.state.BoardState; bs != nil {
+		return bs.CrossCheck(s.Position, a.horizontal)
+	}
 	// Check whether the cross word(s) limit the set of allowed
 	// letters in this anchor square
 	prev, after := a.state.Board.CrossWordFragments(s.Position, !a.horizontal)
 	if len(prev) == 0 && len(after) == 0 {
 		// No cross word, so no cross check constraint
-		return a.rack
+		return AllLetters
 	}
-	return a.state.DAWG.CrossCheck(prev, after)
+	return a.state.DAWG.CrossSet(prev, after)
 }
 
 func (a *Axis) GenerateMoves(leftParts [][]*LeftPart) []Move {
@@ -103,11 +116,14 @@ func (a *Axis) GenerateMoves(leftParts [][]*LeftPart) []Move {
 	lastAnchor := -1
 	// Process the anchors, one by one, from left to right
 	for i := 0; i < BoardSize; i++ {
+		if a.canceled() {
+			break
+		}
 		if !a.IsAnchor(i) {
 			continue
 		}
 		// This is an anchor
-		if len(a.crossCheckLetters[i]) > 0 {
+		if a.crossChecks[i] != 0 {
 			// A tile from the rack can actually be placed here:
 			// count open squares to the anchor's left,
 			// up to but not including the previous anchor, if any.
@@ -120,7 +136,7 @@ func (a *Axis) GenerateMoves(leftParts [][]*LeftPart) []Move {
 				left--
 			}
 			moves = append(moves,
-				a.genMovesFromAnchor(i, min(openCnt, len(a.state.Rack.Tiles)-1), leftParts)...,
+				a.genMovesFromAnchor(i, minInt(openCnt, len(a.state.Rack.Tiles)-1), leftParts)...,
 			)
 		}
 		lastAnchor = i
@@ -157,7 +173,7 @@ func (a *Axis) extendBefore(anchor int) []Move {
 	// which is a list of runes
 	left := make([]rune, len(fragment))
 	for i, tile := range fragment {
-		left[len(fragment)-1-i] = tile.Letter
+		left[len(fragment)-1-i] = unicode.ToLower(tile.Letter)
 	}
 	// Do the DAWG navigation to find the left part
 	var ebn ExtendBeforeNavigator
@@ -195,6 +211,9 @@ func (a *Axis) extendAfter(anchor int, maxLeft int, leftParts [][]*LeftPart) []M
 		// Try all left prefixes of length leftLen
 		leftList := leftParts[leftLen-1]
 		for _, leftPart := range leftList {
+			if a.canceled() {
+				return moves
+			}
 			var ean ExtendAfterNavigator
 			ean.Init(a, anchor, leftPart.rack)
 			DAWG.Resume(&ean, leftPart.navState, leftPart.matched)
@@ -205,6 +224,42 @@ func (a *Axis) extendAfter(anchor int, maxLeft int, leftParts [][]*LeftPart) []M
 	return moves
 }
 
+// isHorizontalSingle returns true if the word of the given length laid
+// from the start index covers a single square, and the tile also forms
+// a horizontal word of at least two letters. Such a move is found both
+// on the horizontal and the vertical axes.
+func (a *Axis) isHorizontalSingle(start, length int) bool {
+	var single *Square
+	for _, sq := range a.squares[start : start+length] {
+		if sq.Tile != nil {
+			continue
+		}
+		if single != nil {
+			return false
+		}
+		single = sq
+	}
+	if single == nil {
+		return false
+	}
+	adj := a.state.Board.Adjacents[single.Position.Row][single.Position.Col]
+	return (adj[DirectionLeft] != nil && adj[DirectionLeft].Tile != nil) ||
+		(adj[DirectionRight] != nil && adj[DirectionRight].Tile != nil)
+}
+
+// canceled returns true if the move generation should stop
+func (a *Axis) canceled() bool {
+	if a.done == nil {
+		return false
+	}
+	select {
+	case <-a.done:
+		return true
+	default:
+		return false
+	}
+}
+
 func (a *Axis) IsAnchor(index int) bool {
 	return a.isAnchor[index]
 }
@@ -212,7 +267,7 @@ func (a *Axis) IsAnchor(index int) bool {
 // IsOpen returns true if the given square within the Axis
 // is open for a new Tile from the Rack
 func (a *Axis) IsOpen(index int) bool {
-	return a.squares[index].Tile == nil && len(a.crossCheckLetters[index]) > 0
+	return a.squares[index].Tile == nil && a.crossChecks[index] != 0
 }
 
 // Allows returns true if the given letter can be placed
@@ -223,12 +278,19 @@ func (a *Axis) Allows(index int, letter rune) bool {
 		return false
 	}
 
-	return slices.Contains(a.crossCheckLetters[index], letter)
+	return a.crossChecks[index].Contains(letter)
 }
 
-func min(i1, i2 int) int {
+func minInt(i1, i2 int) int {
 	if i1 <= i2 {
 		return i1
 	}
 	return i2
 }
+
+func maxInt(i1, i2 int) int {
+	if i1 >= i2 {
+		return i1
+	}
+	return i2
+}
@@ -0,0 +1,25 @@
+package scrabble
+
+import "testing"
+
+func TestGenerateMovesSingleTileOnce(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	// A t left of the blank a forms both ta across and at down
+	state.Rack = newRackOf("t", g.TileSet)
+	pos := Position{Row: 8, Col: 7}
+	count := 0
+	for _, move := range state.GenerateMoves() {
+		if tileMove, ok := move.(*TileMove); ok && len(tileMove.Covers) == 1 {
+			if _, ok := tileMove.Covers[pos]; ok {
+				count++
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("the t at %v was generated %d times, want once", pos, count)
+	}
+	if diff := DiffMoveGenerators(state); !diff.OK() {
+		t.Errorf("%v", diff)
+	}
+}
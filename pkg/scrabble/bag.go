@@ -66,6 +66,26 @@ func NewBag(tileset *TileSet) *Bag {
 	return b
 }
 
+// newBagOf returns a Bag containing one tile for each of the given
+// letters, e.g. to draw from the unseen tiles in a simulation
+func newBagOf(letters string, tileset *TileSet) *Bag {
+	b := &Bag{
+		Tiles:   make([]Tile, 0, len(letters)),
+		TileSet: tileset,
+	}
+
+	for _, letter := range letters {
+		b.Tiles = append(b.Tiles,
+			Tile{
+				Letter: letter,
+				Value:  tileset.Values[letter],
+			},
+		)
+	}
+
+	return b
+}
+
 func (b *Bag) shuffle() {
 	rand.Shuffle(b.TileCount(), func(i, j int) {
 		b.Tiles[i], b.Tiles[j] = b.Tiles[j], b.Tiles[i]
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 const BoardSize int = 15
@@ -11,6 +12,7 @@ const BoardSize int = 15
 var (
 	ErrInvalidPosition = errors.New("position is out of bounds")
 	ErrExistingTile    = errors.New("a tile already exist on that square")
+	ErrNoTile          = errors.New("there is no tile on that square")
 )
 
 var (
@@ -49,13 +51,6 @@ var (
 		"111113111311111",
 		"111211111112111",
 	}
-
-	// row/col Ids are the row identifiers of a board, for printing purposes
-	rowIds = [BoardSize]string{
-		"0", "1", "2", "3", "4",
-		"5", "6", "7", "8", "9",
-		"0", "1", "2", "3", "4",
-	}
 )
 
 type Board struct {
@@ -100,7 +95,13 @@ func NewBoard() *Board {
 		}
 	}
 
-	// Initialize the adjacent square lists
+	b.initAdjacents()
+
+	return b
+}
+
+// initAdjacents initializes the adjacent square lists
+func (b *Board) initAdjacents() {
 	for row := 0; row < BoardSize; row++ {
 		for col := 0; col < BoardSize; col++ {
 			adj := &b.Adjacents[row][col]
@@ -138,8 +139,15 @@ func NewBoard() *Board {
 			}
 		}
 	}
+}
 
-	return b
+// Clone returns a deep copy of the Board, so that tiles can be
+// placed on it without altering the original. Tiles themselves
+// are shared, as they are never modified once on the board.
+func (b *Board) Clone() *Board {
+	c := &Board{Squares: b.Squares}
+	c.initAdjacents()
+	return c
 }
 
 func (b *Board) GetSquare(p Position) *Square {
@@ -159,6 +167,42 @@ func (b *Board) PlaceTile(t *Tile, p Position) error {
 	return nil
 }
 
+// RemoveTile takes the tile off the square at the given position,
+// e.g. to undo a move, and returns it
+func (b *Board) RemoveTile(p Position) (*Tile, error) {
+	if !p.InBounds() {
+		return nil, ErrInvalidPosition
+	}
+	sq := b.GetSquare(p)
+	if sq.Tile == nil {
+		return nil, ErrNoTile
+	}
+	t := sq.Tile
+	sq.Tile = nil
+	return t, nil
+}
+
+// PlaceCovers lays down the tiles described by the covers on the Board,
+// without involving any rack. Blank tiles are placed with their actual
+// letter in uppercase, as TileMove.Apply does.
+func (b *Board) PlaceCovers(covers Covers, tileSet *TileSet) error {
+	for pos, cover := range covers {
+		if err := b.PlaceTile(coverTile(cover, tileSet), pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coverTile returns the tile laid down on the Board by a cover
+func coverTile(cover Cover, tileSet *TileSet) *Tile {
+	tile := &Tile{Letter: cover.Actual, Value: tileSet.Values[cover.Letter]}
+	if cover.Letter == '*' {
+		tile.Letter = unicode.ToUpper(cover.Actual)
+	}
+	return tile
+}
+
 // TileFragment returns a list of the tiles that extend from the square
 // at given pos in the direction specified.
 func (b *Board) TileFragment(pos Position, dir Direction) []*Tile {
@@ -186,7 +230,8 @@ func (b *Board) TileFragment(pos Position, dir Direction) []*Tile {
 
 // WordFragment returns the word formed by the tile sequence emanating
 // from the given square in the indicated direction, not including the
-// square itself.
+// square itself. The letters of blank tiles are in lowercase, as in the
+// dictionary.
 func (b *Board) WordFragment(pos Position, direction Direction) string {
 	result := ""
 	frag := b.TileFragment(pos, direction)
@@ -194,12 +239,12 @@ func (b *Board) WordFragment(pos Position, direction Direction) string {
 	if direction == DirectionLeft || direction == DirectionAbove {
 		// We need to reverse the order of the fragment
 		for _, tile := range frag {
-			result = string(tile.Letter) + result
+			result = string(unicode.ToLower(tile.Letter)) + result
 		}
 	} else {
 		// The fragment is in correct reading order
 		for _, tile := range frag {
-			result += string(tile.Letter)
+			result += string(unicode.ToLower(tile.Letter))
 		}
 	}
 	return result
@@ -272,16 +317,17 @@ func (b *Board) CrossScore(pos Position, horizontal bool) (hasCrossing bool, sco
 	return hasCrossing, score
 }
 
-// String represents a Board as a string
+// String represents a Board as a string, its rows being numbered from 1
+// and its columns lettered from A, as in the coordinates of the moves
 func (b *Board) String() string {
 	var sb strings.Builder
-	sb.WriteString("  ")
+	sb.WriteString("   ")
 	for i := 0; i < BoardSize; i++ {
-		sb.WriteString(rowIds[i] + " ")
+		sb.WriteString(string(rune('A'+i)) + " ")
 	}
 	sb.WriteString("\n")
 	for i := 0; i < BoardSize; i++ {
-		sb.WriteString(fmt.Sprintf("%s ", rowIds[i]))
+		sb.WriteString(fmt.Sprintf("%2d ", i+1))
 		for j := 0; j < BoardSize; j++ {
 			sq := b.GetSquare(Position{i, j})
 			sb.WriteString(fmt.Sprintf("%v ", sq))
@@ -0,0 +1,22 @@
+package scrabble
+
+import "testing"
+
+func TestWordFragmentBlank(t *testing.T) {
+	g := newTestGame(t)
+	tests := []struct {
+		pos       Position
+		direction Direction
+		want      string
+	}{
+		{Position{Row: 10, Col: 8}, DirectionAbove, "tab"},
+		{Position{Row: 6, Col: 8}, DirectionBellow, "tab"},
+		{Position{Row: 8, Col: 7}, DirectionRight, "a"},
+		{Position{Row: 8, Col: 9}, DirectionLeft, "a"},
+	}
+	for _, tt := range tests {
+		if got := g.Board.WordFragment(tt.pos, tt.direction); got != tt.want {
+			t.Errorf("WordFragment(%v, %d) = %q, want %q", tt.pos, tt.direction, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package scrabble
+
+// BoardState is a companion of a Board that holds what the move
+// generation needs to know about each square: whether it is an anchor,
+// and its cross-check sets, i.e. the letters that can be placed on it
+// without forming an invalid cross word, both for tiles laid horizontally
+// and vertically. Rather than being computed from scratch for each move
+// generation, they are updated only around the tiles placed on, or
+// removed from, the board through the BoardState.
+type BoardState struct {
+	Board *Board
+	DAWG  *DAWG
+	// crossChecks[0] is for horizontal moves, the cross words being
+	// vertical, and crossChecks[1] for vertical moves
+	crossChecks [2][BoardSize][BoardSize]LetterSet
+	anchors     [BoardSize][BoardSize]bool
+}
+
+// NewBoardState computes the anchors and the cross-check
+// sets of every square of the board
+func NewBoardState(b *Board, dawg *DAWG) *BoardState {
+	bs := &BoardState{Board: b, DAWG: dawg}
+	for row := 0; row < BoardSize; row++ {
+		for col := 0; col < BoardSize; col++ {
+			pos := Position{Row: row, Col: col}
+			bs.computeAnchor(pos)
+			bs.computeCrossChecks(pos)
+		}
+	}
+	return bs
+}
+
+// Clone returns a copy of the BoardState with a copy of its Board,
+// which can then be modified independently of the original
+func (bs *BoardState) Clone() *BoardState {
+	c := *bs
+	c.Board = bs.Board.Clone()
+	return &c
+}
+
+// cloneBoardState returns a BoardState for a copy of the board of the
+// GameState, to be modified independently of it, e.g. in simulations
+func (gs *GameState) cloneBoardState() *BoardState {
+	if gs.BoardState != nil {
+		return gs.BoardState.Clone()
+	}
+	return NewBoardState(gs.Board.Clone(), gs.DAWG)
+}
+
+// IsAnchor returns true if a move must cover the square at the given
+// position or an adjacent one. Before the first move, only the center
+// square is an anchor.
+func (bs *BoardState) IsAnchor(pos Position) bool {
+	return bs.anchors[pos.Row][pos.Col]
+}
+
+// CrossCheck returns the cross-check set of the square at the
+// given position, for a tile laid horizontally or vertically
+func (bs *BoardState) CrossCheck(pos Position, horizontal bool) LetterSet {
+	if horizontal {
+		return bs.crossChecks[0][pos.Row][pos.Col]
+	}
+	return bs.crossChecks[1][pos.Row][pos.Col]
+}
+
+// PlaceTile places the tile on the Board and updates the anchors
+// and the cross-check sets around it
+func (bs *BoardState) PlaceTile(t *Tile, pos Position) error {
+	if err := bs.Board.PlaceTile(t, pos); err != nil {
+		return err
+	}
+	bs.update(pos)
+	return nil
+}
+
+// RemoveTile takes the tile off the Board, e.g. to undo a move, updates
+// the anchors and the cross-check sets around it and returns the tile
+func (bs *BoardState) RemoveTile(pos Position) (*Tile, error) {
+	t, err := bs.Board.RemoveTile(pos)
+	if err != nil {
+		return nil, err
+	}
+	bs.update(pos)
+	return t, nil
+}
+
+// PlaceCovers lays down the tiles described by the covers on the Board,
+// as Board.PlaceCovers does, updating the BoardState along the way
+func (bs *BoardState) PlaceCovers(covers Covers, tileSet *TileSet) error {
+	for pos, cover := range covers {
+		if err := bs.PlaceTile(coverTile(cover, tileSet), pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveCovers takes the tiles described by the covers off the
+// Board, undoing PlaceCovers
+func (bs *BoardState) RemoveCovers(covers Covers) error {
+	for pos := range covers {
+		if _, err := bs.RemoveTile(pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update recomputes what may have changed after a tile was placed at,
+// or removed from, the given position: the anchors of the square and
+// of its neighbours, and the cross-check sets of the square and of the
+// empty squares ending the lines of tiles through it
+func (bs *BoardState) update(pos Position) {
+	b := bs.Board
+	if pos.Row == BoardCenter && pos.Col == BoardCenter {
+		// The anchors depend on whether the center square is covered
+		for row := 0; row < BoardSize; row++ {
+			for col := 0; col < BoardSize; col++ {
+				bs.computeAnchor(Position{Row: row, Col: col})
+			}
+		}
+	} else {
+		bs.computeAnchor(pos)
+		for _, sq := range b.Adjacents[pos.Row][pos.Col] {
+			if sq != nil {
+				bs.computeAnchor(sq.Position)
+			}
+		}
+	}
+
+	bs.computeCrossChecks(pos)
+	for dir := DirectionAbove; dir <= DirectionBellow; dir++ {
+		sq := b.Adjacents[pos.Row][pos.Col][dir]
+		for sq != nil && sq.Tile != nil {
+			sq = b.Adjacents[sq.Position.Row][sq.Position.Col][dir]
+		}
+		if sq != nil {
+			bs.computeCrossChecks(sq.Position)
+		}
+	}
+}
+
+// computeAnchor computes whether the square at pos is an anchor
+func (bs *BoardState) computeAnchor(pos Position) {
+	b := bs.Board
+	if b.Squares[BoardCenter][BoardCenter].Tile == nil {
+		// If no tile has yet been placed on the board,
+		// the center square is the only anchor
+		bs.anchors[pos.Row][pos.Col] = pos.Row == BoardCenter && pos.Col == BoardCenter
+		return
+	}
+	bs.anchors[pos.Row][pos.Col] = b.GetSquare(pos).IsAnchor(b)
+}
+
+// computeCrossChecks computes both cross-check sets of the square at pos
+func (bs *BoardState) computeCrossChecks(pos Position) {
+	if bs.Board.GetSquare(pos).Tile != nil {
+		// No tile can be placed on an occupied square
+		bs.crossChecks[0][pos.Row][pos.Col] = 0
+		bs.crossChecks[1][pos.Row][pos.Col] = 0
+		return
+	}
+	for i, horizontal := range []bool{true, false} {
+		prev, after := bs.Board.CrossWordFragments(pos, !horizontal)
+		if len(prev) == 0 && len(after) == 0 {
+			// No cross word, so no cross check constraint
+			bs.crossChecks[i][pos.Row][pos.Col] = AllLetters
+			continue
+		}
+		bs.crossChecks[i][pos.Row][pos.Col] = bs.DAWG.CrossSet(prev, after)
+	}
+}
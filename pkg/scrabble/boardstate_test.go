@@ -0,0 +1,96 @@
+package scrabble
+
+import "testing"
+
+func TestBoardStateIncrementalUpdates(t *testing.T) {
+	// Each step places the tile of the letter, uppercase for a blank,
+	// or removes the tile of the square if the letter is 0
+	type step struct {
+		row, col int
+		letter   rune
+	}
+	for _, tt := range []struct {
+		name  string
+		steps []step
+	}{
+		{"first tile taken back", []step{
+			{7, 7, 'a'},
+			{7, 7, 0},
+		}},
+		{"word played and taken back", []step{
+			{7, 6, 'c'}, {7, 7, 'A'}, {7, 8, 't'}, {7, 9, 's'},
+			{8, 8, 'A'}, {9, 8, 'b'},
+			{9, 8, 0}, {8, 8, 0},
+			// The center tile goes before the others
+			{7, 7, 0}, {7, 6, 0}, {7, 9, 0}, {7, 8, 0},
+		}},
+		{"gap filled and emptied", []step{
+			{7, 6, 'c'}, {7, 7, 'a'}, {7, 9, 's'},
+			{7, 8, 'B'},
+			{7, 8, 0},
+			{7, 8, 't'},
+			{7, 7, 0},
+			{7, 7, 'a'},
+		}},
+		{"edges and corners", []step{
+			{7, 7, 'a'},
+			{0, 0, 'c'}, {0, 1, 'a'}, {0, 2, 't'},
+			{14, 14, 'b'}, {13, 14, 'a'}, {12, 14, 't'},
+			{14, 0, 'E'}, {13, 0, 't'},
+			{0, 14, 's'},
+			{0, 1, 0}, {13, 14, 0}, {14, 0, 0}, {0, 14, 0},
+			{0, 1, 'A'},
+			{7, 7, 0},
+		}},
+	} {
+		b := NewBoard()
+		d := newTestDAWG()
+		bs := NewBoardState(b, d)
+		for i, s := range tt.steps {
+			pos := Position{Row: s.row, Col: s.col}
+			if s.letter == 0 {
+				if _, err := bs.RemoveTile(pos); err != nil {
+					t.Fatalf("%s: step %d: %v", tt.name, i, err)
+				}
+			} else if err := bs.PlaceTile(&Tile{Letter: s.letter}, pos); err != nil {
+				t.Fatalf("%s: step %d: %v", tt.name, i, err)
+			}
+
+			fresh := NewBoardState(b, d)
+			if bs.anchors != fresh.anchors {
+				t.Errorf("%s: step %d: the anchors differ from those of a new BoardState", tt.name, i)
+			}
+			if bs.crossChecks != fresh.crossChecks {
+				t.Errorf("%s: step %d: the cross-checks differ from those of a new BoardState", tt.name, i)
+			}
+		}
+	}
+
+	// Once the last tile is removed, the center is the only anchor again
+	bs := NewBoardState(NewBoard(), newTestDAWG())
+	center := Position{Row: BoardCenter, Col: BoardCenter}
+	if err := bs.PlaceTile(&Tile{Letter: 'a'}, center); err != nil {
+		t.Fatal(err)
+	}
+	if bs.IsAnchor(center) || !bs.IsAnchor(Position{Row: BoardCenter, Col: BoardCenter + 1}) {
+		t.Error("the squares next to the first tile should be the anchors")
+	}
+	if _, err := bs.RemoveTile(center); err != nil {
+		t.Fatal(err)
+	}
+	for row := 0; row < BoardSize; row++ {
+		for col := 0; col < BoardSize; col++ {
+			pos := Position{Row: row, Col: col}
+			if bs.IsAnchor(pos) != (pos == center) {
+				t.Errorf("%v is an anchor: %v", pos, bs.IsAnchor(pos))
+			}
+		}
+	}
+
+	if _, err := bs.RemoveTile(center); err != ErrNoTile {
+		t.Errorf("got error %v removing a missing tile, want %v", err, ErrNoTile)
+	}
+	if err := bs.PlaceTile(&Tile{Letter: 'a'}, Position{Row: BoardSize, Col: 0}); err != ErrInvalidPosition {
+		t.Errorf("got error %v placing a tile off the board, want %v", err, ErrInvalidPosition)
+	}
+}
@@ -1,6 +1,8 @@
 package scrabble
 
 import (
+	"context"
+	"math"
 	"math/rand"
 	"sort"
 )
@@ -20,10 +22,21 @@ type Bot struct {
 // GenerateMove generates a list of legal tile moves, then picks a move from
 // with the current bot's strategy
 func (b *Bot) GenerateMove(state *GameState) Move {
+	if s, ok := b.Strategy.(TopKStrategy); ok {
+		// No need to generate more moves than the strategy picks from
+		return b.PickMove(state, state.GenerateTopMoves(s.TopK()))
+	}
 	moves := state.GenerateMoves()
 	return b.PickMove(state, moves)
 }
 
+// GenerateMoveContext is like GenerateMove, but stops generating moves
+// when the context is done and picks a move among the ones found so far
+func (b *Bot) GenerateMoveContext(ctx context.Context, state *GameState) Move {
+	moves, _ := state.GenerateMovesContext(ctx)
+	return b.PickMove(state, moves)
+}
+
 // HighScore strategy always picks the highest-scoring move available, or
 // exchanges all tiles if there is no valid tile move, or passes if exchange is
 // not allowed.
@@ -34,6 +47,20 @@ type OneOfNBest struct {
 	N int
 }
 
+// StaticEval picks the move with the highest equity, i.e. its score
+// plus the value of the tiles it leaves on the rack. Exchanges are
+// evaluated as candidates alongside the tile moves.
+type StaticEval struct {
+	// Leaves values the rack leaves; DefaultLeaves is used if nil
+	Leaves LeaveEvaluator
+}
+
+// EvaluatedMove is a candidate Move along with its equity
+type EvaluatedMove struct {
+	Move   Move
+	Equity float64
+}
+
 // Sort the moves by score
 type byScore struct {
 	state *GameState
@@ -94,6 +121,61 @@ func (ofb *OneOfNBest) PickMove(state *GameState, moves []Move) Move {
 	return NewPassMove()
 }
 
+// PickMove for StaticEval selects the tile move or exchange with the
+// highest equity, or a pass move as a last resort
+func (se *StaticEval) PickMove(state *GameState, moves []Move) Move {
+	candidates := make([]Move, 0, len(moves))
+	candidates = append(candidates, moves...)
+	candidates = append(candidates, state.GenerateExchanges()...)
+	if len(candidates) == 0 {
+		// No tile move and exchange forbidden: Return a pass move
+		return NewPassMove()
+	}
+	var best Move
+	bestEquity := math.Inf(-1)
+	for _, move := range candidates {
+		if equity := se.Equity(state, move); equity > bestEquity {
+			best, bestEquity = move, equity
+		}
+	}
+	return best
+}
+
+// Evaluate returns the tile moves and the allowed exchanges along
+// with their equity, sorted from the highest to the lowest equity
+func (se *StaticEval) Evaluate(state *GameState, moves []Move) []EvaluatedMove {
+	candidates := make([]EvaluatedMove, 0, len(moves))
+	for _, move := range moves {
+		candidates = append(candidates, EvaluatedMove{move, se.Equity(state, move)})
+	}
+	for _, move := range state.GenerateExchanges() {
+		candidates = append(candidates, EvaluatedMove{move, se.Equity(state, move)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Equity > candidates[j].Equity
+	})
+	return candidates
+}
+
+// Equity returns the score of a move plus the value of its rack leave.
+// Once the bag is empty, the leave can no longer be drawn to, and each
+// tile left on the rack is instead counted as a loss of twice its value.
+func (se *StaticEval) Equity(state *GameState, move Move) float64 {
+	equity := float64(move.Score(state))
+	leave := RackLeave(state.Rack.AsString(), move)
+	if state.BagTileCount == 0 {
+		for _, letter := range leave {
+			equity -= float64(2 * state.TileSet.Values[letter])
+		}
+		return equity
+	}
+	leaves := se.Leaves
+	if leaves == nil {
+		leaves = DefaultLeaves
+	}
+	return equity + leaves.LeaveValue(leave)
+}
+
 func NewBot(p *Player, s Strategy) *Bot {
 	return &Bot{
 		Player:   p,
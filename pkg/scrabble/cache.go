@@ -0,0 +1,151 @@
+package scrabble
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Default sizes of the caches of a DAWG, in number of entries
+const (
+	DefaultNodeCacheSize  = 1 << 16
+	DefaultCrossCacheSize = 1 << 16
+)
+
+// lruEvictFraction is the fraction of the entries that an LRUCache
+// evicts at once when it is full, so that evictions are amortized
+const lruEvictFraction = 8
+
+// Cache is a concurrency-safe key/value cache used by a DAWG to
+// remember navigation results. Implementations are free to drop
+// entries at any time. A cache must not be shared between DAWGs.
+type Cache interface {
+	// Get returns the value cached for the key, if any
+	Get(key any) (any, bool)
+	// Put caches the value for the key
+	Put(key, value any)
+	// Stats returns the usage statistics of the cache
+	Stats() CacheStats
+}
+
+// CacheStats holds the usage statistics of a Cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Size is the number of entries in the cache
+	Size int
+}
+
+// Make sure the caches implement the Cache interface
+var (
+	_ Cache = (*LRUCache)(nil)
+	_ Cache = NoCache{}
+)
+
+// LRUCache is a Cache holding at most a given number of entries, which
+// evicts the least recently used ones when full. Reads do not take any
+// lock, so that concurrent move generations do not contend on it; only
+// insertions are serialized.
+type LRUCache struct {
+	capacity int
+	entries  sync.Map
+	// clock orders the accesses to the entries
+	clock atomic.Uint64
+
+	hits, misses, evictions atomic.Uint64
+
+	mu   sync.Mutex
+	size int
+}
+
+type lruEntry struct {
+	value    any
+	lastUsed atomic.Uint64
+}
+
+// NoCache is a Cache that never caches anything
+type NoCache struct{}
+
+// NewLRUCache returns an LRUCache holding at most capacity entries,
+// or an unbounded one if capacity is not positive
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{capacity: capacity}
+}
+
+func (c *LRUCache) Get(key any) (any, bool) {
+	e, ok := c.entries.Load(key)
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	entry := e.(*lruEntry)
+	entry.lastUsed.Store(c.clock.Add(1))
+	return entry.value, true
+}
+
+func (c *LRUCache) Put(key, value any) {
+	entry := &lruEntry{value: value}
+	entry.lastUsed.Store(c.clock.Add(1))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, loaded := c.entries.Load(key)
+	c.entries.Store(key, entry)
+	if loaded {
+		return
+	}
+	c.size++
+	if c.capacity > 0 && c.size > c.capacity {
+		c.evict()
+	}
+}
+
+// evict drops the least recently used entries, a fraction of the
+// capacity at once. The caller must hold the lock.
+func (c *LRUCache) evict() {
+	type keyUse struct {
+		key      any
+		lastUsed uint64
+	}
+	all := make([]keyUse, 0, c.size)
+	c.entries.Range(func(key, e any) bool {
+		all = append(all, keyUse{key: key, lastUsed: e.(*lruEntry).lastUsed.Load()})
+		return true
+	})
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].lastUsed < all[j].lastUsed
+	})
+	n := len(all) - c.capacity + c.capacity/lruEvictFraction
+	if n > len(all) {
+		n = len(all)
+	}
+	for _, ku := range all[:n] {
+		c.entries.Delete(ku.key)
+	}
+	c.size -= n
+	c.evictions.Add(uint64(n))
+}
+
+func (c *LRUCache) Stats() CacheStats {
+	c.mu.Lock()
+	size := c.size
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+	}
+}
+
+func (NoCache) Get(key any) (any, bool) {
+	return nil, false
+}
+
+func (NoCache) Put(key, value any) {}
+
+func (NoCache) Stats() CacheStats {
+	return CacheStats{}
+}
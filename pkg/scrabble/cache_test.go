@@ -0,0 +1,97 @@
+package scrabble
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := NewLRUCache(8)
+	for i := 0; i < 8; i++ {
+		c.Put(i, i*10)
+	}
+	// 0 becomes the most recently used entry
+	if v, ok := c.Get(0); !ok || v != 0 {
+		t.Fatalf("Get(0) = %v, %v", v, ok)
+	}
+	// The cache is full: the least recently used eighth
+	// of the entries is evicted, on top of the extra one
+	c.Put(8, 80)
+	for key, want := range map[int]bool{0: true, 1: false, 2: false, 3: true, 7: true, 8: true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%d) found %v, want %v", key, ok, want)
+		}
+	}
+	if stats := c.Stats(); stats.Evictions != 2 || stats.Size != 7 {
+		t.Errorf("got %+v, want 2 evictions and 7 entries", stats)
+	}
+}
+
+func TestLRUCacheCapacity(t *testing.T) {
+	c := NewLRUCache(8)
+	for i := 0; i < 1000; i++ {
+		c.Put(i, i)
+		if size := c.Stats().Size; size > 8 {
+			t.Fatalf("%d entries in a cache of capacity 8", size)
+		}
+	}
+	// Replacing the value of a key does not add an entry
+	c.Put(999, -1)
+	if v, _ := c.Get(999); v != -1 {
+		t.Errorf("Get(999) = %v after replacing it, want -1", v)
+	}
+	if size := c.Stats().Size; size > 8 {
+		t.Errorf("%d entries after replacing a value", size)
+	}
+
+	// Without a capacity, nothing is evicted
+	c = NewLRUCache(0)
+	for i := 0; i < 1000; i++ {
+		c.Put(i, i)
+	}
+	if stats := c.Stats(); stats.Size != 1000 || stats.Evictions != 0 {
+		t.Errorf("got %+v for an unbounded cache", stats)
+	}
+}
+
+func TestLRUCacheStats(t *testing.T) {
+	c := NewLRUCache(8)
+	c.Get("a")
+	c.Put("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	want := CacheStats{Hits: 2, Misses: 2, Size: 1}
+	if stats := c.Stats(); stats != want {
+		t.Errorf("got %+v, want %+v", stats, want)
+	}
+
+	var nc NoCache
+	nc.Put("a", 1)
+	if _, ok := nc.Get("a"); ok || nc.Stats() != (CacheStats{}) {
+		t.Error("NoCache caches")
+	}
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	c := NewLRUCache(64)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := (g*7 + i) % 100
+				if v, ok := c.Get(key); ok && v != key {
+					t.Errorf("Get(%d) = %v", key, v)
+				}
+				c.Put(key, key)
+			}
+		}(g)
+	}
+	wg.Wait()
+	stats := c.Stats()
+	if stats.Size > 64 || stats.Hits+stats.Misses != 8000 {
+		t.Errorf("got %+v", stats)
+	}
+}
@@ -0,0 +1,44 @@
+package scrabble
+
+// LetterSet is a set of the letters from 'a' to 'z', as a bitmask
+type LetterSet uint32
+
+// AllLetters is the set of every letter of the alphabet
+const AllLetters LetterSet = 1<<26 - 1
+
+// letterSetOf returns the set of the letters in s,
+// ignoring anything that is not a letter from 'a' to 'z'
+func letterSetOf(s string) LetterSet {
+	var ls LetterSet
+	for _, letter := range s {
+		ls = ls.Add(letter)
+	}
+	return ls
+}
+
+// Add returns the set with the letter added to it
+func (ls LetterSet) Add(letter rune) LetterSet {
+	if letter < 'a' || letter > 'z' {
+		return ls
+	}
+	return ls | 1<<(letter-'a')
+}
+
+// Contains returns true if the letter is in the set
+func (ls LetterSet) Contains(letter rune) bool {
+	if letter < 'a' || letter > 'z' {
+		return false
+	}
+	return ls&(1<<(letter-'a')) != 0
+}
+
+// Letters returns the letters of the set in alphabetical order
+func (ls LetterSet) Letters() []rune {
+	letters := make([]rune, 0, 26)
+	for letter := 'a'; letter <= 'z'; letter++ {
+		if ls.Contains(letter) {
+			letters = append(letters, letter)
+		}
+	}
+	return letters
+}
@@ -0,0 +1,181 @@
+package scrabble
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestLetterSet(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		set     LetterSet
+		letters string
+	}{
+		{"empty", 0, ""},
+		{"all letters", AllLetters, "abcdefghijklmnopqrstuvwxyz"},
+		{"letters of a string", letterSetOf("tabs"), "abst"},
+		{"duplicate letters", letterSetOf("aabba"), "ab"},
+		{"not letters", letterSetOf("A*{`0é"), ""},
+		{"first and last", LetterSet(0).Add('a').Add('z'), "az"},
+		{"added twice", letterSetOf("c").Add('c'), "c"},
+		{"uppercase not added", letterSetOf("c").Add('C'), "c"},
+	} {
+		if got := string(tt.set.Letters()); got != tt.letters {
+			t.Errorf("%s: got letters %q, want %q", tt.name, got, tt.letters)
+		}
+		for letter := 'a'; letter <= 'z'; letter++ {
+			want := false
+			for _, l := range tt.letters {
+				want = want || l == letter
+			}
+			if tt.set.Contains(letter) != want {
+				t.Errorf("%s: Contains(%q) = %v, want %v", tt.name, letter, !want, want)
+			}
+		}
+		for _, letter := range "A*{`" {
+			if tt.set.Contains(letter) {
+				t.Errorf("%s: contains %q", tt.name, letter)
+			}
+		}
+	}
+}
+
+// bruteCrossCheck computes the cross-check set of the square at pos by
+// reading the cross word off the squares and looking up every letter
+func bruteCrossCheck(b *Board, d *DAWG, pos Position, horizontal bool) LetterSet {
+	if b.Squares[pos.Row][pos.Col].Tile != nil {
+		return 0
+	}
+	// The cross word of a horizontal move is vertical
+	dRow, dCol := 1, 0
+	if !horizontal {
+		dRow, dCol = 0, 1
+	}
+	letterAt := func(row, col int) (rune, bool) {
+		if row < 0 || col < 0 || row >= BoardSize || col >= BoardSize || b.Squares[row][col].Tile == nil {
+			return 0, false
+		}
+		return unicode.ToLower(b.Squares[row][col].Tile.Letter), true
+	}
+	var prev, after string
+	for row, col := pos.Row-dRow, pos.Col-dCol; ; row, col = row-dRow, col-dCol {
+		letter, ok := letterAt(row, col)
+		if !ok {
+			break
+		}
+		prev = string(letter) + prev
+	}
+	for row, col := pos.Row+dRow, pos.Col+dCol; ; row, col = row+dRow, col+dCol {
+		letter, ok := letterAt(row, col)
+		if !ok {
+			break
+		}
+		after += string(letter)
+	}
+	if prev == "" && after == "" {
+		return AllLetters
+	}
+	var ls LetterSet
+	for letter := 'a'; letter <= 'z'; letter++ {
+		if d.IsWord(prev + string(letter) + after) {
+			ls = ls.Add(letter)
+		}
+	}
+	return ls
+}
+
+// coversOf returns the covers of a word laid from pos, a '*' before a
+// letter making it a blank
+func coversOf(word string, pos Position, horizontal bool) Covers {
+	covers := make(Covers)
+	blank := false
+	for _, letter := range word {
+		if letter == '*' {
+			blank = true
+			continue
+		}
+		cover := Cover{Letter: letter, Actual: letter}
+		if blank {
+			cover.Letter = '*'
+			blank = false
+		}
+		covers[pos] = cover
+		if horizontal {
+			pos.Col++
+		} else {
+			pos.Row++
+		}
+	}
+	return covers
+}
+
+func TestCrossChecksMatchBruteForce(t *testing.T) {
+	d := newTestDAWG()
+	for _, tt := range []struct {
+		name  string
+		words []Covers
+	}{
+		{"empty board", nil},
+		{"cats and tab", []Covers{
+			coversOf("cats", Position{Row: 7, Col: 6}, true),
+			coversOf("*ab", Position{Row: 8, Col: 8}, false),
+		}},
+		{"edges and corners", []Covers{
+			coversOf("cats", Position{Row: 0, Col: 0}, true),
+			coversOf("tab", Position{Row: 12, Col: 14}, false),
+			coversOf("e*at", Position{Row: 14, Col: 5}, true),
+			coversOf("b*e", Position{Row: 0, Col: 14}, false),
+		}},
+		{"gaps between tiles", []Covers{
+			coversOf("c*a", Position{Row: 3, Col: 2}, true),
+			coversOf("s", Position{Row: 3, Col: 5}, true),
+			coversOf("be", Position{Row: 5, Col: 4}, false),
+			coversOf("*ta", Position{Row: 0, Col: 4}, false),
+		}},
+	} {
+		b := NewBoard()
+		bs := NewBoardState(b, d)
+		for _, covers := range tt.words {
+			if err := bs.PlaceCovers(covers, DefaultTileSet); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+		fresh := NewBoardState(b, d)
+		for row := 0; row < BoardSize; row++ {
+			for col := 0; col < BoardSize; col++ {
+				pos := Position{Row: row, Col: col}
+				for _, horizontal := range []bool{true, false} {
+					want := bruteCrossCheck(b, d, pos, horizontal)
+					if got := bs.CrossCheck(pos, horizontal); got != want {
+						t.Errorf("%s: got cross-check %q at %v (horizontal %v), want %q",
+							tt.name, string(got.Letters()), pos, horizontal, string(want.Letters()))
+					}
+					if got := fresh.CrossCheck(pos, horizontal); got != want {
+						t.Errorf("%s: got cross-check %q at %v (horizontal %v) from a new BoardState, want %q",
+							tt.name, string(got.Letters()), pos, horizontal, string(want.Letters()))
+					}
+				}
+			}
+		}
+	}
+
+	// Spot check the gap between "ca", with a blank a, and "s", which
+	// only b and t fill, and above "be", which nothing precedes
+	b := NewBoard()
+	bs := NewBoardState(b, d)
+	for _, covers := range []Covers{
+		coversOf("c*a", Position{Row: 3, Col: 2}, true),
+		coversOf("s", Position{Row: 3, Col: 5}, true),
+		coversOf("be", Position{Row: 5, Col: 4}, false),
+	} {
+		if err := bs.PlaceCovers(covers, DefaultTileSet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := string(bs.CrossCheck(Position{Row: 3, Col: 4}, false).Letters()); got != "bt" {
+		t.Errorf("got cross-check %q between ca and s, want bt", got)
+	}
+	if got := bs.CrossCheck(Position{Row: 4, Col: 4}, true); got != 0 {
+		t.Errorf("got cross-check %q above be, want none", string(got.Letters()))
+	}
+}
@@ -2,7 +2,6 @@ package scrabble
 
 import (
 	"errors"
-	"sync"
 )
 
 var (
@@ -11,46 +10,40 @@ var (
 )
 
 type DAWG struct {
-	Root          *Node
-	iterNodeCache iterNodeCache
-	crossCache    crossCache
+	Root *Node
+	// nodeCache maps nodes to the navStates of their edges.
+	// It helps to not traverse the dawg again when already traversed
+	nodeCache Cache
+	// crossCache stores the available letters for a given key
+	// A key is like all* where the available words are "alla", "alle" and "allo",
+	// so the set is {'a', 'e', 'o'}
+	crossCache Cache
 }
 
+// DawgOption configures a DAWG built by NewDawg
+type DawgOption func(*DAWG)
+
 type Node struct {
 	IsWord bool
 	Edges  map[rune]*Node
 }
 
-// iterNodeCache is a cached map of nodes to navState
-// It helps to not traverse the dawg again when already traversed
-type iterNodeCache struct {
-	mu    sync.Mutex
-	cache map[*Node][]navState
-}
-
-// crossCache stores the available letters for a given key
-// A key is like all* where the available words are "alla", "alle" and "allo",
-// so the runes are ['a', 'e', 'o']
-type crossCache struct {
-	mu    sync.Mutex
-	cache map[string][]rune
-}
-
 func NewNode() *Node {
 	return &Node{
 		Edges: make(map[rune]*Node),
 	}
 }
 
-func NewDawg(dict *Dictionary) *DAWG {
+// NewDawg builds a DAWG holding the words of the dictionary. Unless
+// configured otherwise, its caches are LRUCaches of the default sizes.
+func NewDawg(dict *Dictionary, opts ...DawgOption) *DAWG {
 	d := &DAWG{
-		Root: NewNode(),
-		iterNodeCache: iterNodeCache{
-			cache: make(map[*Node][]navState),
-		},
-		crossCache: crossCache{
-			cache: make(map[string][]rune),
-		},
+		Root:       NewNode(),
+		nodeCache:  NewLRUCache(DefaultNodeCacheSize),
+		crossCache: NewLRUCache(DefaultCrossCacheSize),
+	}
+	for _, opt := range opts {
+		opt(d)
 	}
 
 	for _, word := range dict.Words {
@@ -60,6 +53,26 @@ func NewDawg(dict *Dictionary) *DAWG {
 	return d
 }
 
+// WithNodeCache makes the DAWG cache the edges of its nodes in c
+func WithNodeCache(c Cache) DawgOption {
+	return func(d *DAWG) {
+		d.nodeCache = c
+	}
+}
+
+// WithCrossCache makes the DAWG cache its cross-check sets in c
+func WithCrossCache(c Cache) DawgOption {
+	return func(d *DAWG) {
+		d.crossCache = c
+	}
+}
+
+// CacheStats returns the usage statistics of the node
+// and the cross-check set caches of the DAWG
+func (d *DAWG) CacheStats() (nodes, cross CacheStats) {
+	return d.nodeCache.Stats(), d.crossCache.Stats()
+}
+
 func (d *DAWG) insert(word string) {
 	curr := d.Root
 	for _, letter := range word {
@@ -110,10 +123,10 @@ func (d *DAWG) Navigate(navigator Navigator) {
 const NoPrefix = '_'
 
 // Resume resumes a navigation through the DAWG under the
-// control of a Navigator, from a previously saved state
+// control of a Navigator, from a previously saved state.
+// The state is not modified, so it can be resumed concurrently.
 func (d *DAWG) Resume(navigator Navigator, state *navState, matched string) {
 	var nav Navigation
-	state.prefix = NoPrefix
 	nav.Resume(d, navigator, state, matched)
 }
 
@@ -121,34 +134,41 @@ func (d *DAWG) Resume(navigator Navigator, state *navState, matched string) {
 // in a cross-check set, given a left/top and right/bottom
 // string that intersects the square being checked.
 func (d *DAWG) CrossCheck(prev, after string) []rune {
+	return d.CrossSet(prev, after).Letters()
+}
+
+// CrossSet returns the set of allowed letters in a cross-check
+// set, given a left/top and right/bottom string that intersects
+// the square being checked.
+func (d *DAWG) CrossSet(prev, after string) LetterSet {
 	lenLeft := len(prev)
 	key := prev + "*" + after
-	fetchFunc := func(key string) []rune {
+	fetchFunc := func(key string) LetterSet {
 		// Find all matches for key in DAWG and add the letter corresponding
-		// to the wildcard * to the slice of letters.
-		matches := d.Match(key)
-		// Collect the 'middle' letters (the ones standing in
-		// for the wildcard)
-		letters := make([]rune, 0, len(matches))
-		for _, match := range matches {
-			match := []rune(match)
-			letters = append(letters, match[lenLeft])
+		// to the wildcard * to the set of letters.
+		var letters LetterSet
+		for _, match := range d.Match(key) {
+			letters = letters.Add([]rune(match)[lenLeft])
 		}
 		return letters
 	}
 
-	return d.crossCache.lookup(key, fetchFunc)
-}
-
-// iterNode traverses through all node edges and adds the navigation state
-// to the cache if not already present
-func (i *iterNodeCache) iterNode(n *Node) []navState {
-	i.mu.Lock()
-	defer i.mu.Unlock()
-	if result, ok := i.cache[n]; ok {
-		return result
+	if letters, ok := d.crossCache.Get(key); ok {
+		return letters.(LetterSet)
+	}
+	// Concurrent lookups of a missing key may both compute it,
+	// which is harmless as they get the same result
+	letters := fetchFunc(key)
+	d.crossCache.Put(key, letters)
+	return letters
+}
+
+// iterNode returns the navigation states of all the edges of the node,
+// from the cache if they were already computed
+func (d *DAWG) iterNode(n *Node) []navState {
+	if result, ok := d.nodeCache.Get(n); ok {
+		return result.([]navState)
 	}
-	originalNode := n
 
 	result := make([]navState, 0, len(n.Edges))
 
@@ -162,22 +182,11 @@ func (i *iterNodeCache) iterNode(n *Node) []navState {
 	}
 
 	// Add navigaion result to cache
-	i.cache[originalNode] = result
+	d.nodeCache.Put(n, result)
 
 	return result
 }
 
-func (cc *crossCache) lookup(key string, fetchFunc func(string) []rune) []rune {
-	cc.mu.Lock()
-	defer cc.mu.Unlock()
-	if runes, ok := cc.cache[key]; ok {
-		return runes
-	}
-	runes := fetchFunc(key)
-	cc.cache[key] = runes
-	return runes
-}
-
 // FindLeftParts returns all left part permutations that can be generated
 // from the given rack, grouped by length
 func (dawg *DAWG) FindLeftParts(rack string) [][]*LeftPart {
@@ -36,3 +36,26 @@ func NewCustomDictionary(dictName string) *Dictionary {
 
 	return dict
 }
+
+// LoadDictionary reads a dictionary from the word list at the given
+// path, with one word per line
+func LoadDictionary(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dict := &Dictionary{}
+	sc := bufio.NewScanner(f)
+	sc.Split(bufio.ScanLines)
+
+	for sc.Scan() {
+		dict.Words = append(dict.Words, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return dict, nil
+}
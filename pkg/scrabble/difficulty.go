@@ -0,0 +1,254 @@
+package scrabble
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Difficulty int
+
+// Difficulty levels, from the weakest to the strongest
+const (
+	Beginner Difficulty = iota
+	Casual
+	Intermediate
+	Advanced
+	Expert
+)
+
+var ErrInvalidFrequencyList = errors.New("invalid word frequency list")
+
+// Make sure the difficulty strategy implements the Strategy interface
+var _ Strategy = (*DifficultyStrategy)(nil)
+
+// Level describes how a DifficultyStrategy restricts its play to
+// mimic a human player of a given strength
+type Level struct {
+	// VocabularySize is the number of most frequent words the bot
+	// knows; every word formed by a move must be among them. There is
+	// no limit if zero or if the strategy has no word frequencies.
+	VocabularySize int
+	// MaxWordLength is the length of the longest main word the bot
+	// plays; no limit if zero
+	MaxWordLength int
+	// BingoMissRate is the probability that the bot overlooks
+	// the moves using its whole rack on a given turn
+	BingoMissRate float64
+	// TargetSpread makes the bot pick moves keeping its score within
+	// MinSpread and MaxSpread points of its opponent's score, rather
+	// than the best move
+	TargetSpread         bool
+	MinSpread, MaxSpread int
+}
+
+// Levels holds the presets for each Difficulty
+var Levels = map[Difficulty]Level{
+	Beginner: {
+		VocabularySize: 5000,
+		MaxWordLength:  5,
+		BingoMissRate:  1,
+		TargetSpread:   true,
+		MinSpread:      -60,
+		MaxSpread:      0,
+	},
+	Casual: {
+		VocabularySize: 15000,
+		MaxWordLength:  6,
+		BingoMissRate:  0.75,
+		TargetSpread:   true,
+		MinSpread:      -30,
+		MaxSpread:      30,
+	},
+	Intermediate: {
+		VocabularySize: 40000,
+		MaxWordLength:  8,
+		BingoMissRate:  0.4,
+		TargetSpread:   true,
+		MinSpread:      0,
+		MaxSpread:      80,
+	},
+	Advanced: {
+		VocabularySize: 100000,
+		BingoMissRate:  0.1,
+	},
+	Expert: {},
+}
+
+// WordFrequencies ranks words from the most to the least frequent
+// in everyday language, as read from a word frequency list
+type WordFrequencies struct {
+	ranks map[string]int
+}
+
+// DifficultyStrategy plays like a human of a given strength. It only
+// knows the most frequent words, caps the length of its words, sometimes
+// misses its bingos and, at the lower levels, targets a score close to
+// its opponent's. Among the remaining moves, it picks the one with the
+// highest equity.
+type DifficultyStrategy struct {
+	Level
+	// Frequencies limits the vocabulary of the bot; nil means
+	// that it knows every word of the dictionary
+	Frequencies *WordFrequencies
+	// Static evaluates the remaining moves; a StaticEval with
+	// default leaves is used if nil
+	Static *StaticEval
+}
+
+// NewDifficultyStrategy returns a strategy using the preset Level
+// of the given Difficulty
+func NewDifficultyStrategy(d Difficulty, frequencies *WordFrequencies) *DifficultyStrategy {
+	return &DifficultyStrategy{
+		Level:       Levels[d],
+		Frequencies: frequencies,
+	}
+}
+
+// LoadWordFrequencies reads a word frequency list from the file at the
+// given path, see ReadWordFrequencies
+func LoadWordFrequencies(path string) (*WordFrequencies, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadWordFrequencies(f)
+}
+
+// ReadWordFrequencies parses a word frequency list, with one word per
+// line. Each word may be followed by its number of occurrences, in which
+// case words are ranked by decreasing count; otherwise the list must be
+// ordered from the most to the least frequent word.
+func ReadWordFrequencies(r io.Reader) (*WordFrequencies, error) {
+	type entry struct {
+		word  string
+		count int
+	}
+	entries := make([]entry, 0)
+
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanLines)
+
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		e := entry{word: strings.ToLower(fields[0])}
+		if len(fields) > 1 {
+			count, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidFrequencyList, lineNum, err)
+			}
+			e.count = count
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	// Stable, so that lists without counts keep their order
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].count > entries[j].count
+	})
+	wf := &WordFrequencies{ranks: make(map[string]int, len(entries))}
+	for _, e := range entries {
+		if _, ok := wf.ranks[e.word]; !ok {
+			wf.ranks[e.word] = len(wf.ranks)
+		}
+	}
+	return wf, nil
+}
+
+// Rank returns the rank of the word, 0 being the most frequent word,
+// and false if the word is not in the list
+func (wf *WordFrequencies) Rank(word string) (int, bool) {
+	rank, ok := wf.ranks[strings.ToLower(word)]
+	return rank, ok
+}
+
+// Knows returns true if the word is among the n most frequent words
+func (wf *WordFrequencies) Knows(word string, n int) bool {
+	rank, ok := wf.Rank(word)
+	return ok && rank < n
+}
+
+// PickMove for DifficultyStrategy selects a move among the ones the
+// bot knows, or an exchange move, or a pass move as a last resort
+func (ds *DifficultyStrategy) PickMove(state *GameState, moves []Move) Move {
+	// # nosec
+	missBingos := rand.Float64() < ds.BingoMissRate
+	known := make([]Move, 0, len(moves))
+	for _, move := range moves {
+		if ds.knows(state, move, missBingos) {
+			known = append(known, move)
+		}
+	}
+	static := ds.Static
+	if static == nil {
+		static = &StaticEval{}
+	}
+	if len(known) == 0 || !ds.TargetSpread {
+		return static.PickMove(state, known)
+	}
+
+	// Pick at random among the moves that keep the spread
+	// within the target range...
+	inRange := make([]Move, 0, len(known))
+	for _, move := range known {
+		spread := state.Spread + move.Score(state)
+		if spread >= ds.MinSpread && spread <= ds.MaxSpread {
+			inRange = append(inRange, move)
+		}
+	}
+	if len(inRange) > 0 {
+		// # nosec
+		return inRange[rand.Intn(len(inRange))]
+	}
+	// ...or else play the move that gets the closest to it
+	var closest Move
+	bestDistance := -1
+	for _, move := range known {
+		spread := state.Spread + move.Score(state)
+		distance := ds.MinSpread - spread
+		if spread > ds.MaxSpread {
+			distance = spread - ds.MaxSpread
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			closest, bestDistance = move, distance
+		}
+	}
+	return closest
+}
+
+// knows returns true if the move is within the bot's abilities
+func (ds *DifficultyStrategy) knows(state *GameState, move Move, missBingos bool) bool {
+	tileMove, ok := move.(*TileMove)
+	if !ok {
+		return true
+	}
+	if missBingos && len(tileMove.Covers) == RackSize {
+		return false
+	}
+	if ds.MaxWordLength > 0 && len([]rune(tileMove.Word)) > ds.MaxWordLength {
+		return false
+	}
+	if ds.Frequencies == nil || ds.VocabularySize == 0 {
+		return true
+	}
+	for _, word := range tileMove.Words(state.Board) {
+		if !ds.Frequencies.Knows(word, ds.VocabularySize) {
+			return false
+		}
+	}
+	return true
+}
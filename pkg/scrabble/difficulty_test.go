@@ -0,0 +1,90 @@
+package scrabble
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadWordFrequencies(t *testing.T) {
+	// Without counts, the list is ordered from the most frequent word
+	wf, err := ReadWordFrequencies(strings.NewReader("the\nCat\n\nsat\ncat\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]int{"the": 0, "cat": 1, "sat": 2} {
+		if rank, ok := wf.Rank(word); !ok || rank != want {
+			t.Errorf("Rank(%q) = %d, %v, want %d", word, rank, ok, want)
+		}
+	}
+	if !wf.Knows("cat", 2) || wf.Knows("sat", 2) || wf.Knows("dog", 10) {
+		t.Error("Knows does not limit the vocabulary to the most frequent words")
+	}
+
+	// With counts, words are ranked by decreasing count
+	wf, err = ReadWordFrequencies(strings.NewReader("sat 5\ncat 20\nthe 100\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank, _ := wf.Rank("sat"); rank != 2 {
+		t.Errorf("Rank(sat) = %d, want 2", rank)
+	}
+
+	if _, err := ReadWordFrequencies(strings.NewReader("cat many\n")); !errors.Is(err, ErrInvalidFrequencyList) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidFrequencyList)
+	}
+}
+
+func TestTileMoveWords(t *testing.T) {
+	g := newTestGame(t)
+	// b and e below the c and a of cats make bea across, with the blank
+	// a, and cb and ae down
+	move := NewTileMove(g.Board, Covers{
+		{Row: 8, Col: 6}: {Letter: 'b', Actual: 'b'},
+		{Row: 8, Col: 7}: {Letter: 'e', Actual: 'e'},
+	})
+	got := strings.Join(move.Words(g.Board), " ")
+	if got != "bea cb ae" && got != "bea ae cb" {
+		t.Errorf("got words %q", got)
+	}
+}
+
+func TestDifficultyStrategy(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+	state.Spread = 0
+	moves := state.GenerateMoves()
+	wf, err := ReadWordFrequencies(strings.NewReader("cats\ntab\ntabs\nat\nta\nbat\nbats\neat\neats\nbeast\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds := &DifficultyStrategy{
+		Level:       Level{VocabularySize: 9, MaxWordLength: 4, BingoMissRate: 1},
+		Frequencies: wf,
+	}
+	for i := 0; i < 10; i++ {
+		move, ok := ds.PickMove(state, moves).(*TileMove)
+		if !ok {
+			continue
+		}
+		if len([]rune(move.Word)) > 4 {
+			t.Errorf("%v is longer than 4 letters", move)
+		}
+		for _, word := range move.Words(state.Board) {
+			if !wf.Knows(word, 9) {
+				t.Errorf("%v forms the unknown word %q", move, word)
+			}
+		}
+	}
+
+	// Targeting the spread, the moves are within the range if possible
+	ds = &DifficultyStrategy{Level: Level{TargetSpread: true, MinSpread: 5, MaxSpread: 8}}
+	for i := 0; i < 10; i++ {
+		move := ds.PickMove(state, moves)
+		if score := move.Score(state); score < 5 || score > 8 {
+			t.Errorf("%v scores %d, out of the target range", move, score)
+		}
+	}
+}
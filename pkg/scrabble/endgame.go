@@ -0,0 +1,323 @@
+package scrabble
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultEndgameDepth = 4
+
+// endgameInfinity is larger than any possible spread
+const endgameInfinity = 1 << 30
+
+var ErrBagNotEmpty = errors.New("the bag is not empty")
+
+// Make sure the solver implements the Strategy interface
+var _ Strategy = (*EndgameSolver)(nil)
+
+// EndgameSolver searches the endgame exhaustively once the bag is empty.
+// Both racks are then known, as the opponent's rack is made of all the
+// unseen tiles, so the position is a game of perfect information. The
+// solver runs an alpha-beta search with iterative deepening, move
+// ordering and a transposition table.
+type EndgameSolver struct {
+	// MaxDepth is the maximum number of plies searched;
+	// DefaultEndgameDepth is used if zero
+	MaxDepth int
+	// Budget is the maximum time spent searching, no limit if zero.
+	// The result of the deepest completed iteration is used.
+	Budget time.Duration
+	// Fallback picks the move while there are still tiles in
+	// the bag; a StaticEval with default leaves is used if nil
+	Fallback Strategy
+}
+
+// EndgameResult is the outcome of an endgame search
+type EndgameResult struct {
+	// Moves is the best sequence found, starting with the move
+	// of the player to move and alternating between the players
+	Moves []Move
+	// Spread is the number of points the player to move gains over the
+	// opponent, from now to the end of the sequence, including the
+	// final rack adjustments if the game ends within it
+	Spread int
+	// Depth is the depth of the deepest completed iteration
+	Depth int
+	// Exact is true if every line was searched to the end of the game,
+	// so that Spread is the final outcome under perfect play
+	Exact bool
+}
+
+type endgameSearch struct {
+	state    *GameState
+	board    *BoardState
+	racks    [2]string
+	hash     uint64
+	table    map[endgameKey]*endgameEntry
+	deadline time.Time
+	timeout  bool
+	hitLimit bool
+}
+
+type endgameKey struct {
+	board  uint64
+	racks  [2]string
+	toMove int
+	passes int
+}
+
+type endgameBound int
+
+const (
+	boundExact endgameBound = iota
+	boundLower
+	boundUpper
+)
+
+type endgameEntry struct {
+	depth int
+	value int
+	bound endgameBound
+	// exact is true if no line below was cut by the depth limit
+	exact bool
+	best  string
+	pv    []Move
+}
+
+// PickMove for EndgameSolver plays the first move of the best sequence
+// when the bag is empty, and defers to the Fallback strategy otherwise
+func (es *EndgameSolver) PickMove(state *GameState, moves []Move) Move {
+	result, err := es.Solve(state)
+	if err != nil || len(result.Moves) == 0 {
+		fallback := es.Fallback
+		if fallback == nil {
+			fallback = &StaticEval{}
+		}
+		return fallback.PickMove(state, moves)
+	}
+	return result.Moves[0]
+}
+
+// Solve searches the endgame from the given state and returns the best
+// sequence of moves. The bag must be empty.
+func (es *EndgameSolver) Solve(state *GameState) (*EndgameResult, error) {
+	return es.solve(state, time.Time{})
+}
+
+// solve is Solve, the search also stopping at the given deadline if it
+// is not zero and comes before the end of the Budget
+func (es *EndgameSolver) solve(state *GameState, deadline time.Time) (*EndgameResult, error) {
+	if state.BagTileCount != 0 {
+		return nil, ErrBagNotEmpty
+	}
+	maxDepth := es.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultEndgameDepth
+	}
+	s := &endgameSearch{
+		state: state,
+		board: state.cloneBoardState(),
+		racks: [2]string{
+			SortLetters(state.Rack.AsString()),
+			state.UnseenLetters(),
+		},
+		table:    make(map[endgameKey]*endgameEntry),
+		deadline: deadline,
+	}
+	if es.Budget > 0 {
+		if budget := time.Now().Add(es.Budget); s.deadline.IsZero() || budget.Before(s.deadline) {
+			s.deadline = budget
+		}
+	}
+
+	result := &EndgameResult{}
+	for depth := 1; depth <= maxDepth; depth++ {
+		s.hitLimit = false
+		value, pv := s.negamax(depth, -endgameInfinity, endgameInfinity, 0, 0)
+		if s.timeout {
+			// This iteration is incomplete: keep the previous one
+			break
+		}
+		result = &EndgameResult{Moves: pv, Spread: value, Depth: depth, Exact: !s.hitLimit}
+		if result.Exact {
+			// Searching deeper would not change anything
+			break
+		}
+	}
+	return result, nil
+}
+
+// negamax returns the number of points the player toMove gains over the
+// opponent from this position, along with the best sequence of moves
+func (s *endgameSearch) negamax(depth, alpha, beta, toMove, passes int) (int, []Move) {
+	own, opp := s.racks[toMove], s.racks[1-toMove]
+	if passes >= 2 {
+		// Both players passed in a row: nothing will change anymore, so
+		// the game ends by passes and each player gets the other's rack
+		return rackLettersValue(opp, s.state.TileSet) - rackLettersValue(own, s.state.TileSet), nil
+	}
+	if depth == 0 {
+		// Depth limit: assume the tiles left on the racks are lost
+		s.hitLimit = true
+		return rackLettersValue(opp, s.state.TileSet) - rackLettersValue(own, s.state.TileSet), nil
+	}
+	if !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		s.timeout = true
+		return 0, nil
+	}
+
+	key := endgameKey{board: s.hash, racks: s.racks, toMove: toMove, passes: passes}
+	entry := s.table[key]
+	if entry != nil && (entry.depth >= depth || entry.exact) {
+		switch entry.bound {
+		case boundExact:
+			if !entry.exact {
+				s.hitLimit = true
+			}
+			return entry.value, entry.pv
+		case boundLower:
+			alpha = maxInt(alpha, entry.value)
+		case boundUpper:
+			beta = minInt(beta, entry.value)
+		}
+		if alpha >= beta {
+			if !entry.exact {
+				s.hitLimit = true
+			}
+			return entry.value, entry.pv
+		}
+	}
+
+	state := &GameState{
+		DAWG:         s.state.DAWG,
+		TileSet:      s.state.TileSet,
+		Board:        s.board.Board,
+		BoardState:   s.board,
+		Rack:         newRackOf(own, s.state.TileSet),
+		BagTileCount: 0,
+	}
+	moves := state.GenerateMoves()
+	// Search the moves that score the most first...
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].Score(state) > moves[j].Score(state)
+	})
+	// ...passing being always possible...
+	moves = append(moves, NewPassMove())
+	// ...but start with the best move of a previous iteration
+	if entry != nil {
+		for i, move := range moves {
+			if moveKey(move) == entry.best {
+				copy(moves[1:i+1], moves[:i])
+				moves[0] = move
+				break
+			}
+		}
+	}
+
+	origAlpha := alpha
+	hitLimit := s.hitLimit
+	s.hitLimit = false
+	bestValue := -endgameInfinity
+	var bestPV []Move
+	for _, move := range moves {
+		var value int
+		var pv []Move
+		score := move.Score(state)
+		switch m := move.(type) {
+		case *TileMove:
+			leave := RackLeave(own, m)
+			s.place(m)
+			s.racks[toMove] = leave
+			if leave == "" {
+				// Went out: the game is over and the player gets
+				// twice the value of the opponent's rack
+				value = score + 2*rackLettersValue(opp, s.state.TileSet)
+			} else {
+				var v int
+				v, pv = s.negamax(depth-1, -beta, -alpha, 1-toMove, 0)
+				value = score - v
+			}
+			s.racks[toMove] = own
+			s.remove(m)
+		default:
+			var v int
+			v, pv = s.negamax(depth-1, -beta, -alpha, 1-toMove, passes+1)
+			value = -v
+		}
+		if s.timeout {
+			return 0, nil
+		}
+		if value > bestValue {
+			bestValue = value
+			bestPV = append([]Move{move}, pv...)
+		}
+		alpha = maxInt(alpha, value)
+		if alpha >= beta {
+			break
+		}
+	}
+
+	entry = &endgameEntry{
+		depth: depth,
+		value: bestValue,
+		bound: boundExact,
+		exact: !s.hitLimit,
+		best:  moveKey(bestPV[0]),
+		pv:    bestPV,
+	}
+	if bestValue <= origAlpha {
+		entry.bound = boundUpper
+	} else if bestValue >= beta {
+		entry.bound = boundLower
+	}
+	s.table[key] = entry
+	s.hitLimit = s.hitLimit || hitLimit
+
+	return bestValue, bestPV
+}
+
+func (s *endgameSearch) place(move *TileMove) {
+	if err := s.board.PlaceCovers(move.Covers, s.state.TileSet); err != nil {
+		// Should not happen with generated moves
+		panic(err)
+	}
+	for pos, cover := range move.Covers {
+		s.hash ^= tileHash(pos, cover)
+	}
+}
+
+func (s *endgameSearch) remove(move *TileMove) {
+	_ = s.board.RemoveCovers(move.Covers)
+	for pos, cover := range move.Covers {
+		s.hash ^= tileHash(pos, cover)
+	}
+}
+
+// tileHash returns a pseudo random hash for a tile on a square, used
+// to identify board positions by xoring the hashes of all their tiles
+func tileHash(pos Position, cover Cover) uint64 {
+	x := uint64(pos.Row*BoardSize+pos.Col)<<42 ^ uint64(cover.Letter)<<21 ^ uint64(cover.Actual)
+	// splitmix64 finalizer
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// moveKey returns a string that identifies a move
+// independently of the Move instance
+func moveKey(move Move) string {
+	tileMove, ok := move.(*TileMove)
+	if !ok {
+		return move.String()
+	}
+	keys := make([]string, 0, len(tileMove.Covers))
+	for pos, cover := range tileMove.Covers {
+		keys = append(keys, fmt.Sprintf("%d,%d%c%c", pos.Row, pos.Col, cover.Letter, cover.Actual))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ";")
+}
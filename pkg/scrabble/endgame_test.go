@@ -0,0 +1,92 @@
+package scrabble
+
+import (
+	"testing"
+	"time"
+)
+
+// newEndgameState returns the state of an endgame on the board of
+// newTestGame, with an empty bag, the given rack for the player to
+// move and the given rack for the opponent
+func newEndgameState(t *testing.T, rack, opp string) *GameState {
+	t.Helper()
+	g := newTestGame(t)
+	// The tiles of the game are the ones on the board and the racks
+	tileSet := &TileSet{Count: map[rune]int{}, Values: DefaultTileSet.Values}
+	for _, letter := range "cats*b" + rack + opp {
+		tileSet.Count[letter]++
+	}
+	return &GameState{
+		DAWG:       g.DAWG,
+		TileSet:    tileSet,
+		Board:      g.Board,
+		BoardState: g.BoardState,
+		Rack:       newRackOf(rack, tileSet),
+	}
+}
+
+func TestEndgameSolver(t *testing.T) {
+	// s can only be played to make scats (7 points) or tabs (5 points),
+	// and the opponent cannot play its q
+	scats := moveKey(&TileMove{Covers: Covers{{Row: 7, Col: 5}: {Letter: 's', Actual: 's'}}})
+	tests := []struct {
+		name   string
+		rack   string
+		opp    string
+		first  string
+		spread int
+	}{
+		// Going out with scats gets twice the value of the q
+		{"going out", "s", "q", scats, 7 + 2*8},
+		// Stuck with the q, a pass is answered by scats
+		{"stuck", "q", "s", moveKey(NewPassMove()), -(7 + 2*8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newEndgameState(t, tt.rack, tt.opp)
+			before := state.Board.String()
+			result, err := (&EndgameSolver{}).Solve(state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Exact {
+				t.Errorf("the result of depth %d is not exact", result.Depth)
+			}
+			if result.Spread != tt.spread {
+				t.Errorf("spread %d, want %d", result.Spread, tt.spread)
+			}
+			if len(result.Moves) == 0 || moveKey(result.Moves[0]) != tt.first {
+				t.Errorf("got moves %v, want to start with %s", result.Moves, tt.first)
+			}
+			if state.Board.String() != before {
+				t.Errorf("the search changed the board:\n%v", state.Board)
+			}
+		})
+	}
+}
+
+func TestEndgameSolverBagNotEmpty(t *testing.T) {
+	state := newEndgameState(t, "s", "q")
+	state.BagTileCount = 1
+	if _, err := (&EndgameSolver{}).Solve(state); err != ErrBagNotEmpty {
+		t.Errorf("got error %v, want %v", err, ErrBagNotEmpty)
+	}
+}
+
+func TestEndgameSolverBudget(t *testing.T) {
+	state := newEndgameState(t, "aestbce", "aeesttb")
+	es := &EndgameSolver{MaxDepth: 100, Budget: 20 * time.Millisecond}
+	start := time.Now()
+	result, err := es.Solve(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*es.Budget {
+		t.Errorf("searching took %v with a budget of %v", elapsed, es.Budget)
+	}
+	// Searching this endgame to the end takes much longer: the
+	// result is the one of the deepest iteration completed in time
+	if result.Exact || len(result.Moves) == 0 {
+		t.Errorf("got %v moves, exact: %v, at depth %d", result.Moves, result.Exact, result.Depth)
+	}
+}
@@ -1,5 +1,10 @@
 package scrabble
 
+import (
+	"context"
+	"sync"
+)
+
 const MaxPassMoves int = 6
 
 type Game struct {
@@ -11,6 +16,20 @@ type Game struct {
 	MoveList     []*MoveItem
 	Finished     bool
 	NumPassMoves int
+	// BoardState is kept up to date as tiles are played,
+	// to speed up the move generation
+	BoardState *BoardState
+	// OnOver, if not nil, is called once the game is over
+	// and the final moves have been scored
+	OnOver func(g *Game)
+	// HintPenalties are the points a hint of each level
+	// costs; hints are free if nil
+	HintPenalties map[HintLevel]int
+
+	// The hints given to the player to move, and their
+	// penalty, recorded with their next move
+	pendingHints   []HintLevel
+	pendingPenalty int
 }
 
 // GameState contains the bare minimum of information
@@ -22,6 +41,14 @@ type GameState struct {
 	Board           *Board
 	Rack            *Rack
 	ExchangeAllowed bool
+	BagTileCount    int
+	// BoardState, when not nil, holds the anchors and the cross-check
+	// sets of the Board, which must be its Board; they are computed on
+	// the fly otherwise
+	BoardState *BoardState
+	// Spread is the score of the player to move minus
+	// the score of the opponent
+	Spread int
 }
 
 // MoveItem is an entry in the MoveList of a Game.
@@ -30,14 +57,20 @@ type GameState struct {
 type MoveItem struct {
 	RackBefore string
 	Move       Move
+	// Hints are the hints the player was given before the move,
+	// and Penalty the points they cost, deducted from the score
+	Hints   []HintLevel
+	Penalty int
 }
 
 func NewGame(tileSet *TileSet, dawg *DAWG) *Game {
+	board := NewBoard()
 	g := &Game{
-		Board:   NewBoard(),
-		DAWG:    dawg,
-		Bag:     NewBag(tileSet),
-		TileSet: tileSet,
+		Board:      board,
+		DAWG:       dawg,
+		Bag:        NewBag(tileSet),
+		TileSet:    tileSet,
+		BoardState: NewBoardState(board, dawg),
 	}
 
 	return g
@@ -64,7 +97,11 @@ func (g *Game) PlayTile(t *Tile, pos Position, r *Rack) error {
 		return err
 	}
 
-	err = g.Board.PlaceTile(t, pos)
+	if g.BoardState != nil {
+		err = g.BoardState.PlaceTile(t, pos)
+	} else {
+		err = g.Board.PlaceTile(t, pos)
+	}
 	if err != nil {
 		return err
 	}
@@ -79,6 +116,7 @@ func (g *Game) ApplyValid(move Move) error {
 	// Be careful to call PlayerToMove() before appending
 	// a move to the move list (this reverses the players)
 	playerToMove := g.PlayerToMove()
+	opponent := g.Players[1-g.PlayerToMoveIndex()]
 	rackBefore := playerToMove.Rack.AsString()
 	if err := move.Apply(g); err != nil {
 		// Should not happen because it should be a valid move
@@ -90,7 +128,7 @@ func (g *Game) ApplyValid(move Move) error {
 	if g.IsOver() {
 		// The game is now over: add the FinalMoves
 		rackPlayer := playerToMove.Rack.AsString()
-		rackOpp := g.Players[1-g.PlayerToMoveIndex()].Rack.AsString()
+		rackOpp := opponent.Rack.AsString()
 
 		multiplyFactor := 2
 		if len(rackPlayer) > 0 {
@@ -99,10 +137,15 @@ func (g *Game) ApplyValid(move Move) error {
 			// opponent's remaining tile scores
 			multiplyFactor = 1
 		}
-		// Add a final move for the finishing player
-		g.scoreMove(rackPlayer, NewFinalMove(rackOpp, multiplyFactor))
-		// Add a final move for the opponent
+		// The opponent is now to move: add its final move first,
+		// getting the finishing player's remaining tile scores...
 		g.scoreMove(rackOpp, NewFinalMove(rackPlayer, multiplyFactor))
+		// ...then the final move of the finishing player
+		g.scoreMove(rackPlayer, NewFinalMove(rackOpp, multiplyFactor))
+		g.Finished = true
+		if g.OnOver != nil {
+			g.OnOver(g)
+		}
 	}
 	return nil
 }
@@ -110,13 +153,19 @@ func (g *Game) ApplyValid(move Move) error {
 // scoreMove updates the scores and appends a given Move
 // to the Game's MoveList
 func (g *Game) scoreMove(rackBefore string, move Move) {
-	// Calculate the score
-	score := move.Score(g.State())
+	// Calculate the score, minus the penalty of the hints
+	score := move.Score(g.State()) - g.pendingPenalty
 	// Update the player's score
 	g.PlayerToMove().Score += score
 	// Append to the move list
-	moveItem := &MoveItem{RackBefore: rackBefore, Move: move}
+	moveItem := &MoveItem{
+		RackBefore: rackBefore,
+		Move:       move,
+		Hints:      g.pendingHints,
+		Penalty:    g.pendingPenalty,
+	}
 	g.MoveList = append(g.MoveList, moveItem)
+	g.pendingHints, g.pendingPenalty = nil, 0
 }
 
 // IsOver returns true if the Game is over after the last
@@ -139,12 +188,19 @@ func (g *Game) IsOver() bool {
 // State returns a new GameState instance describing the state of the
 // game in a minimal manner so that a robot player can decide on a move
 func (g *Game) State() *GameState {
+	spread := g.PlayerToMove().Score
+	if opp := g.Players[1-g.PlayerToMoveIndex()]; opp != nil {
+		spread -= opp.Score
+	}
 	return &GameState{
 		DAWG:            g.DAWG,
 		TileSet:         g.TileSet,
 		Board:           g.Board,
 		Rack:            g.PlayerToMove().Rack,
 		ExchangeAllowed: g.Bag.ExchangeAllowed(),
+		BagTileCount:    g.Bag.TileCount(),
+		BoardState:      g.BoardState,
+		Spread:          spread,
 	}
 }
 
@@ -174,9 +230,100 @@ func (gs *GameState) GenerateMoves() []Move {
 	return moves
 }
 
+// GenerateMovesContext is like GenerateMoves, but stops as soon as the
+// context is canceled or its deadline expires. It then returns the moves
+// found so far, along with the context's error.
+func (gs *GameState) GenerateMovesContext(ctx context.Context) ([]Move, error) {
+	moves := make([]Move, 0)
+	err := gs.StreamMoves(ctx, func(move Move) {
+		moves = append(moves, move)
+	})
+	return moves, err
+}
+
+// StreamMoves generates the moves like GenerateMovesContext, but calls
+// fn with each move as soon as it is found instead of collecting them.
+// Calls to fn are never concurrent. StreamMoves returns once all the
+// moves have been generated or the context is done.
+func (gs *GameState) StreamMoves(ctx context.Context, fn func(Move)) error {
+	leftParts := gs.DAWG.FindLeftParts(gs.Rack.AsString())
+
+	var mu sync.Mutex
+	emit := func(move Move) {
+		mu.Lock()
+		defer mu.Unlock()
+		fn(move)
+	}
+
+	// Start the 30 goroutines (columns and rows = 2 * BoardSize)
+	var wg sync.WaitGroup
+	for index := 0; index < BoardSize; index++ {
+		for _, horizontal := range []bool{true, false} {
+			wg.Add(1)
+			go func(index int, horizontal bool) {
+				defer wg.Done()
+				var axis Axis
+				axis.Init(gs, index, horizontal)
+				axis.done = ctx.Done()
+				axis.emit = emit
+				axis.GenerateMoves(leftParts)
+			}(index, horizontal)
+		}
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// MovesChan streams the generated moves through the returned channel,
+// which is closed once all the moves have been generated or the context
+// is done. The caller must either drain the channel or cancel the context.
+func (gs *GameState) MovesChan(ctx context.Context) <-chan Move {
+	movesChan := make(chan Move, BoardSize*2)
+	go func() {
+		defer close(movesChan)
+		_ = gs.StreamMoves(ctx, func(move Move) {
+			select {
+			case movesChan <- move:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return movesChan
+}
+
 func (gs *GameState) GenerateMovesOnAxis(index int, horizontal bool, leftParts [][]*LeftPart, resultsChan chan<- []Move) {
 	var axis Axis
 	axis.Init(gs, index, horizontal)
 	// Generate a list of moves and send it on the result channel
 	resultsChan <- axis.GenerateMoves(leftParts)
 }
+
+// GenerateExchanges returns every distinct ExchangeMove that can be
+// made with the current Rack, or nil if exchanges are not allowed
+func (gs *GameState) GenerateExchanges() []Move {
+	if !gs.ExchangeAllowed {
+		return nil
+	}
+	rack := []rune(SortLetters(gs.Rack.AsString()))
+	seen := make(map[string]bool)
+	moves := make([]Move, 0)
+	// Each bit of the mask tells whether the tile at that
+	// index of the rack is exchanged
+	for mask := 1; mask < 1<<len(rack); mask++ {
+		letters := make([]rune, 0, len(rack))
+		for i, letter := range rack {
+			if mask&(1<<i) != 0 {
+				letters = append(letters, letter)
+			}
+		}
+		key := string(letters)
+		if seen[key] {
+			// Same letters as another exchange, because of duplicates
+			continue
+		}
+		seen[key] = true
+		moves = append(moves, NewExchangeMove(key))
+	}
+	return moves
+}
@@ -0,0 +1,259 @@
+package scrabble
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+var (
+	englishDAWGOnce sync.Once
+	englishDAWG     *DAWG
+)
+
+// loadEnglishDAWG returns the DAWG of the English dictionary of the
+// assets, skipping the test if it cannot be found
+func loadEnglishDAWG(t *testing.T) *DAWG {
+	t.Helper()
+	englishDAWGOnce.Do(func() {
+		dict, err := LoadDictionary(filepath.Join("..", "..", "assets", "defaultEN.txt"))
+		if err == nil {
+			englishDAWG = NewDawg(dict)
+		}
+	})
+	if englishDAWG == nil {
+		t.Skip("English dictionary not found")
+	}
+	return englishDAWG
+}
+
+// TestScriptedGames replays the short games scripted by hand in
+// testdata/scripted, each checking a scoring rule, e.g. the bingo bonus
+// or the end of the game by passes, and checks the score of every move
+// and the final scores. Each line of a game is one of:
+//
+//	bag <letters>                            set the tiles left in the bag
+//	rack <A|B> <letters>                     set the rack of a player
+//	play <row> <col> <across|down> <word> <score>
+//	exchange <letters>
+//	pass
+//	final <score A> <score B>
+//
+// The word of a play includes the letters already on the board, and
+// blank tiles are written in uppercase.
+func TestScriptedGames(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "scripted", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no scripted games found")
+	}
+	dawg := loadEnglishDAWG(t)
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			replayScriptedGame(t, dawg, file)
+		})
+	}
+}
+
+func replayScriptedGame(t *testing.T, dawg *DAWG, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	g := NewGame(DefaultTileSet, dawg)
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+
+	sc := bufio.NewScanner(f)
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var move Move
+		switch fields[0] {
+		case "bag":
+			letters := ""
+			if len(fields) > 1 {
+				letters = fields[1]
+			}
+			g.Bag = newBagOf(letters, g.TileSet)
+			continue
+		case "rack":
+			player := g.Players[0]
+			if fields[1] == "B" {
+				player = g.Players[1]
+			}
+			player.Rack = newRackOf(fields[2], g.TileSet)
+			continue
+		case "play":
+			row, _ := strconv.Atoi(fields[1])
+			col, _ := strconv.Atoi(fields[2])
+			move = scriptedTileMove(t, g.Board, Position{Row: row, Col: col}, fields[3] == "across", fields[4])
+			want, _ := strconv.Atoi(fields[5])
+			if score := move.Score(g.State()); score != want {
+				t.Errorf("line %d: %v scores %d, want %d", lineNum, move, score, want)
+			}
+		case "exchange":
+			move = NewExchangeMove(fields[1])
+		case "pass":
+			move = NewPassMove()
+		case "final":
+			if !g.IsOver() {
+				t.Fatalf("line %d: the game is not over", lineNum)
+			}
+			for i, field := range fields[1:] {
+				want, _ := strconv.Atoi(field)
+				if score := g.Players[i].Score; score != want {
+					t.Errorf("line %d: player %s scores %d, want %d", lineNum, g.Players[i].Username, score, want)
+				}
+			}
+			continue
+		default:
+			t.Fatalf("line %d: unknown command %q", lineNum, fields[0])
+		}
+		if g.IsOver() {
+			t.Fatalf("line %d: the game is already over", lineNum)
+		}
+		if !move.IsValid(g) {
+			t.Fatalf("line %d: %v is not valid", lineNum, move)
+		}
+		if err := g.ApplyValid(move); err != nil {
+			t.Fatalf("line %d: %v", lineNum, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// scriptedTileMove returns the TileMove laying the word from the given
+// position, skipping the letters that are already on the board
+func scriptedTileMove(t *testing.T, b *Board, pos Position, across bool, word string) *TileMove {
+	t.Helper()
+	covers := make(Covers)
+	for _, letter := range word {
+		if !pos.InBounds() {
+			t.Fatalf("%s goes off the board", word)
+		}
+		actual := unicode.ToLower(letter)
+		if tile := b.GetSquare(pos).Tile; tile != nil {
+			if unicode.ToLower(tile.Letter) != actual {
+				t.Fatalf("%s does not match the %c on the board at %v", word, tile.Letter, pos)
+			}
+		} else if unicode.IsUpper(letter) {
+			covers[pos] = Cover{Letter: '*', Actual: actual}
+		} else {
+			covers[pos] = Cover{Letter: letter, Actual: actual}
+		}
+		if across {
+			pos.Col++
+		} else {
+			pos.Row++
+		}
+	}
+	return NewTileMove(b, covers)
+}
+
+func TestFinalMoves(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+	g.Players[0].Rack = newRackOf("at", g.TileSet)
+	g.Players[1].Rack = newRackOf("bc", g.TileSet)
+	g.Bag = newBagOf("", g.TileSet)
+
+	// A plays out, B being left with b and c
+	move := NewTileMove(g.Board, Covers{
+		{Row: BoardCenter, Col: BoardCenter}:     {Letter: 'a', Actual: 'a'},
+		{Row: BoardCenter, Col: BoardCenter + 1}: {Letter: 't', Actual: 't'},
+	})
+	if !move.IsValid(g) {
+		t.Fatalf("%v should be valid", move)
+	}
+	score := move.Score(g.State())
+	if err := g.ApplyValid(move); err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsOver() {
+		t.Fatal("the game should be over")
+	}
+	rackB := g.TileSet.Values['b'] + g.TileSet.Values['c']
+	if got, want := g.Players[0].Score, score+2*rackB; got != want {
+		t.Errorf("the finishing player scored %d, want %d", got, want)
+	}
+	if got := g.Players[1].Score; got != 0 {
+		t.Errorf("the opponent scored %d, want 0", got)
+	}
+}
+
+func TestGenerateMovesContext(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+	all := state.GenerateMoves()
+
+	moves, err := state.GenerateMovesContext(context.Background())
+	if err != nil || len(moves) != len(all) {
+		t.Errorf("got %d moves and error %v, want %d moves", len(moves), err, len(all))
+	}
+
+	// Canceled before it starts, the generation returns early
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	moves, err = state.GenerateMovesContext(ctx)
+	if !errors.Is(err, context.Canceled) || len(moves) >= len(all) {
+		t.Errorf("got %d of %d moves and error %v, want %v", len(moves), len(all), err, context.Canceled)
+	}
+}
+
+func TestStreamMoves(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+	all := make(map[string]bool)
+	for _, move := range state.GenerateMoves() {
+		all[coversKey(move.(*TileMove).Covers)] = true
+	}
+
+	streamed := 0
+	err := state.StreamMoves(context.Background(), func(move Move) {
+		if !all[coversKey(move.(*TileMove).Covers)] {
+			t.Errorf("%v is not generated by GenerateMoves", move)
+		}
+		streamed++
+	})
+	if err != nil || streamed != len(all) {
+		t.Errorf("streamed %d moves with error %v, want %d", streamed, err, len(all))
+	}
+
+	// Canceling while streaming stops the generation
+	ctx, cancel := context.WithCancel(context.Background())
+	streamed = 0
+	err = state.StreamMoves(ctx, func(Move) {
+		streamed++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) || streamed >= len(all) {
+		t.Errorf("streamed %d of %d moves with error %v after canceling", streamed, len(all), err)
+	}
+
+	received := 0
+	for range state.MovesChan(context.Background()) {
+		received++
+	}
+	if received != len(all) {
+		t.Errorf("received %d moves from the channel, want %d", received, len(all))
+	}
+}
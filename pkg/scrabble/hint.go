@@ -0,0 +1,118 @@
+package scrabble
+
+import "fmt"
+
+// HintLevel tells how much a hint discloses about the best move.
+// Each level discloses more than the previous one.
+type HintLevel int
+
+const (
+	// HintBingo tells whether a bingo can be played
+	HintBingo HintLevel = iota
+	// HintScore tells the score of the best move
+	HintScore
+	// HintSquare tells a square covered by the best move
+	HintSquare
+	// HintWord reveals the best move
+	HintWord
+)
+
+// Hint helps the player to move find the highest scoring move
+type Hint struct {
+	Level HintLevel
+	// Text is the hint as shown to the player
+	Text string
+	// Move is the highest scoring move, nil if there is no tile move.
+	// It discloses more than Text at the lower levels.
+	Move *TileMove
+	// Penalty is the number of points the hint costs
+	Penalty int
+}
+
+// String returns the name of the HintLevel
+func (level HintLevel) String() string {
+	switch level {
+	case HintBingo:
+		return "bingo"
+	case HintScore:
+		return "score"
+	case HintSquare:
+		return "square"
+	case HintWord:
+		return "word"
+	}
+	return fmt.Sprintf("HintLevel(%d)", int(level))
+}
+
+// Hint returns a hint of the given level about the highest scoring
+// tile move of the player to move
+func (gs *GameState) Hint(level HintLevel) *Hint {
+	hint := &Hint{Level: level}
+	var bingo bool
+	for _, move := range gs.GenerateMoves() {
+		tileMove := move.(*TileMove)
+		if hint.Move == nil || tileMove.Score(gs) > hint.Move.Score(gs) {
+			hint.Move = tileMove
+		}
+		bingo = bingo || len(tileMove.Covers) == RackSize
+	}
+	if hint.Move == nil {
+		hint.Text = "There is no move: exchange or pass"
+		return hint
+	}
+
+	switch level {
+	case HintBingo:
+		hint.Text = "There is no bingo"
+		if bingo {
+			hint.Text = "There is a bingo"
+		}
+	case HintScore:
+		hint.Text = fmt.Sprintf("The best move scores %d points", hint.Move.Score(gs))
+	case HintSquare:
+		hint.Text = fmt.Sprintf("The best move covers %s", hintSquare(gs.Board, hint.Move).Coordinate(true))
+	default:
+		hint.Text = fmt.Sprintf("The best move is %s", hint.Move.Notation(gs.Board))
+	}
+	return hint
+}
+
+// hintSquare returns the square covered by the move with the highest
+// premium, or the first one if the move covers no premium square
+func hintSquare(b *Board, move *TileMove) Position {
+	best, bestPremium := move.Start, 0
+	for pos := range move.Covers {
+		sq := b.GetSquare(pos)
+		premium := sq.LetterMultiplier * sq.WordMultiplier
+		if premium > bestPremium || (premium == bestPremium && positionBefore(pos, best)) {
+			best, bestPremium = pos, premium
+		}
+	}
+	return best
+}
+
+// positionBefore returns true if p comes before q, reading
+// the board from left to right and top to bottom
+func positionBefore(p, q Position) bool {
+	return p.Row < q.Row || (p.Row == q.Row && p.Col < q.Col)
+}
+
+// Hint returns a hint of the given level to the player to move, see
+// GameState.Hint. Its penalty, given by the HintPenalties of the
+// Game, is deducted from the score of their next move. A hint finding
+// no move, or of a level already given in the turn, costs nothing.
+func (g *Game) Hint(level HintLevel) *Hint {
+	hint := g.State().Hint(level)
+	if hint.Move == nil {
+		return hint
+	}
+	for _, given := range g.pendingHints {
+		if given == level {
+			return hint
+		}
+	}
+	hint.Penalty = g.HintPenalties[level]
+	g.pendingHints = append(g.pendingHints, level)
+	g.pendingPenalty += hint.Penalty
+	return hint
+}
@@ -0,0 +1,85 @@
+package scrabble
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHint(t *testing.T) {
+	g := newTestGame(t)
+	g.PlayerToMove().Rack = newRackOf("bestxyz", g.TileSet)
+	g.HintPenalties = map[HintLevel]int{HintSquare: 2, HintWord: 5}
+
+	word := g.Hint(HintWord)
+	if word.Move == nil {
+		t.Fatal("no move found")
+	}
+	state := g.State()
+	score := word.Move.Score(state)
+	for _, move := range state.GenerateMoves() {
+		if move.Score(state) > score {
+			t.Fatalf("%v scores more than the hinted %v", move, word.Move)
+		}
+	}
+	tests := []struct {
+		hint *Hint
+		want string
+	}{
+		{g.Hint(HintBingo), "There is no bingo"},
+		{g.Hint(HintScore), "The best move scores " + strconv.Itoa(score) + " points"},
+		{word, "The best move is " + word.Move.Notation(g.Board)},
+	}
+	for _, tt := range tests {
+		if tt.hint.Text != tt.want {
+			t.Errorf("got %s hint %q, want %q", tt.hint.Level, tt.hint.Text, tt.want)
+		}
+	}
+	square := g.Hint(HintSquare)
+	pos, _, err := ParseCoordinate(strings.TrimPrefix(square.Text, "The best move covers "))
+	if _, ok := word.Move.Covers[pos]; err != nil || !ok {
+		t.Errorf("got square hint %q for %s", square.Text, word.Move.Notation(g.Board))
+	}
+
+	// Asking again for a hint of the turn costs nothing
+	for _, level := range []HintLevel{HintWord, HintSquare} {
+		if again := g.Hint(level); again.Penalty != 0 || again.Text == "" {
+			t.Errorf("got penalty %d for the %s hint asked again", again.Penalty, level)
+		}
+	}
+
+	// The penalties of the hints are recorded with the next move
+	player := g.PlayerToMove()
+	if err := g.ApplyValid(word.Move); err != nil {
+		t.Fatal(err)
+	}
+	item := g.MoveList[len(g.MoveList)-1]
+	if item.Penalty != 7 || len(item.Hints) != 4 || player.Score != score-7 {
+		t.Errorf("got penalty %d for hints %v and score %d, want 7 and %d", item.Penalty, item.Hints, player.Score, score-7)
+	}
+	if err := g.ApplyValid(NewPassMove()); err != nil {
+		t.Fatal(err)
+	}
+	if item := g.MoveList[len(g.MoveList)-1]; item.Penalty != 0 || item.Hints != nil {
+		t.Errorf("the hints were recorded twice")
+	}
+}
+
+func TestHintWithoutMove(t *testing.T) {
+	g := newTestGame(t)
+	g.PlayerToMove().Rack = newRackOf("zzzzzzz", g.TileSet)
+	g.HintPenalties = map[HintLevel]int{HintBingo: 1, HintScore: 1, HintSquare: 2, HintWord: 5}
+
+	for level := HintBingo; level <= HintWord; level++ {
+		hint := g.Hint(level)
+		if hint.Move != nil || hint.Penalty != 0 {
+			t.Errorf("got move %v and penalty %d for the %s hint", hint.Move, hint.Penalty, level)
+		}
+	}
+	if err := g.ApplyValid(NewPassMove()); err != nil {
+		t.Fatal(err)
+	}
+	if item := g.MoveList[len(g.MoveList)-1]; item.Penalty != 0 || item.Hints != nil {
+		t.Errorf("got penalty %d for hints %v without a move", item.Penalty, item.Hints)
+	}
+}
@@ -0,0 +1,126 @@
+package scrabble
+
+import (
+	"sort"
+	"strings"
+)
+
+// LeaveEvaluator is an interface for anything that can tell how
+// valuable it is to keep a given set of letters on the rack
+// after a move
+type LeaveEvaluator interface {
+	LeaveValue(leave string) float64
+}
+
+// Make sure the leave evaluators implement the LeaveEvaluator interface
+var _ LeaveEvaluator = (*HeuristicLeaves)(nil)
+
+// HeuristicLeaves values a rack leave from a few hand tuned rules: a value
+// for each single tile, a penalty for each duplicated tile, bonuses or
+// penalties for letter combinations such as "qu", and a penalty when the
+// leave is unbalanced between vowels and consonants.
+type HeuristicLeaves struct {
+	// Tiles is the value of keeping each individual tile
+	Tiles map[rune]float64
+	// Duplicate is added for each copy of a letter beyond the first one
+	Duplicate float64
+	// Synergies are added when all the letters of the key are kept
+	Synergies map[string]float64
+	// Balance is added for each vowel or consonant in excess of
+	// an acceptable spread between them
+	Balance float64
+}
+
+const vowels = "aeiouy"
+
+var DefaultLeaves = &HeuristicLeaves{
+	Tiles: map[rune]float64{
+		'*': 25.0, 's': 8.0, 'e': 3.5, 'r': 1.5, 'a': 1.0, 'n': 1.0,
+		'l': 0.5, 't': 0.5, 'i': 0.0, 'd': 0.0, 'o': -1.0, 'c': -0.5,
+		'm': -0.5, 'p': -1.0, 'h': -1.0, 'x': 2.5, 'z': 2.0, 'u': -3.0,
+		'g': -2.5, 'b': -2.5, 'f': -2.5, 'y': -1.5, 'k': -1.5, 'w': -3.0,
+		'j': -2.5, 'v': -5.5, 'q': -7.0,
+	},
+	Duplicate: -3.5,
+	Synergies: map[string]float64{
+		"qu": 7.0, "er": 1.5, "es": 1.5, "ing": 3.0, "**": -6.0,
+		"ss": 3.0, "ck": 1.0, "ch": 1.0,
+	},
+	Balance: -2.5,
+}
+
+// LeaveValue returns the heuristic equity of keeping the given letters
+func (hl *HeuristicLeaves) LeaveValue(leave string) float64 {
+	value := 0.0
+	counts := make(map[rune]int, len(leave))
+	numVowels, numConsonants := 0, 0
+	for _, letter := range leave {
+		value += hl.Tiles[letter]
+		counts[letter]++
+		if counts[letter] > 1 {
+			value += hl.Duplicate
+		}
+		switch {
+		case letter == '*':
+			// Blanks are neither vowels nor consonants
+		case strings.ContainsRune(vowels, letter):
+			numVowels++
+		default:
+			numConsonants++
+		}
+	}
+	for combo, bonus := range hl.Synergies {
+		if containsLetters(counts, combo) {
+			value += bonus
+		}
+	}
+	// Allow one more vowel than consonants, or the reverse, but
+	// penalize anything beyond that
+	spread := numVowels - numConsonants
+	if spread < 0 {
+		spread = -spread
+	}
+	if spread > 1 {
+		value += hl.Balance * float64(spread-1)
+	}
+	return value
+}
+
+// containsLetters returns true if every letter of combo,
+// repeats included, is present in the given letter counts
+func containsLetters(counts map[rune]int, combo string) bool {
+	needed := make(map[rune]int, len(combo))
+	for _, letter := range combo {
+		needed[letter]++
+	}
+	for letter, n := range needed {
+		if counts[letter] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// RackLeave returns the letters that remain on the rack after the given
+// move has been played, sorted alphabetically. Blank tiles are kept as '*'.
+func RackLeave(rack string, move Move) string {
+	switch m := move.(type) {
+	case *TileMove:
+		for _, cover := range m.Covers {
+			rack = strings.Replace(rack, string(cover.Letter), "", 1)
+		}
+	case *ExchangeMove:
+		for _, letter := range m.Letters {
+			rack = strings.Replace(rack, string(letter), "", 1)
+		}
+	}
+	return SortLetters(rack)
+}
+
+// SortLetters returns the letters of s in alphabetical order,
+// which is the canonical form of a rack or a leave
+func SortLetters(s string) string {
+	letters := []rune(s)
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
@@ -0,0 +1,87 @@
+package scrabble
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeuristicLeaves(t *testing.T) {
+	hl := &HeuristicLeaves{
+		Tiles:     map[rune]float64{'s': 8, 'q': -7, 'u': -3, 'e': 3, 'a': 1},
+		Duplicate: -3,
+		Synergies: map[string]float64{"qu": 7},
+		Balance:   -2,
+	}
+	tests := []struct {
+		leave string
+		want  float64
+	}{
+		{"", 0},
+		{"s", 8},
+		// Two consonants, one beyond the allowed spread
+		{"ss", 8 + 8 - 3 - 2},
+		// q and u together get the synergy
+		{"qu", -7 - 3 + 7},
+		// Three vowels and no consonant: two beyond the allowed spread
+		{"aae", 1 + 1 - 3 + 3 - 2*2},
+	}
+	for _, tt := range tests {
+		if got := hl.LeaveValue(tt.leave); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("LeaveValue(%q) = %v, want %v", tt.leave, got, tt.want)
+		}
+	}
+}
+
+func TestRackLeave(t *testing.T) {
+	move := &TileMove{Covers: Covers{
+		{Row: 7, Col: 7}: {Letter: 't', Actual: 't'},
+		{Row: 7, Col: 8}: {Letter: '*', Actual: 'a'},
+	}}
+	if got := RackLeave("tse*at", move); got != "aest" {
+		t.Errorf("RackLeave of a tile move = %q, want %q", got, "aest")
+	}
+	if got := RackLeave("tse*at", NewExchangeMove("tt")); got != "*aes" {
+		t.Errorf("RackLeave of an exchange = %q, want %q", got, "*aes")
+	}
+	if got := RackLeave("tse*at", NewPassMove()); got != "*aestt" {
+		t.Errorf("RackLeave of a pass = %q, want %q", got, "*aestt")
+	}
+}
+
+func TestGenerateExchanges(t *testing.T) {
+	state := &GameState{TileSet: DefaultTileSet, Rack: newRackOf("aab", DefaultTileSet), ExchangeAllowed: true}
+	// a, b, aa, ab and aab
+	if got := len(state.GenerateExchanges()); got != 5 {
+		t.Errorf("%d exchanges, want 5", got)
+	}
+	state.ExchangeAllowed = false
+	if got := state.GenerateExchanges(); got != nil {
+		t.Errorf("%d exchanges while they are not allowed", len(got))
+	}
+}
+
+func TestStaticEvalEquity(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	state := &GameState{
+		DAWG:         g.DAWG,
+		TileSet:      g.TileSet,
+		Board:        g.Board,
+		Rack:         newRackOf("atq", g.TileSet),
+		BagTileCount: 10,
+	}
+	move := NewTileMove(g.Board, Covers{
+		{Row: BoardCenter, Col: BoardCenter}:     {Letter: 'a', Actual: 'a'},
+		{Row: BoardCenter, Col: BoardCenter + 1}: {Letter: 't', Actual: 't'},
+	})
+	score := float64(move.Score(state))
+	se := &StaticEval{Leaves: &HeuristicLeaves{Tiles: map[rune]float64{'q': -7}}}
+	if got := se.Equity(state, move); got != score-7 {
+		t.Errorf("equity %v, want %v", got, score-7)
+	}
+	// With an empty bag, the q is lost twice
+	state.BagTileCount = 0
+	want := score - float64(2*g.TileSet.Values['q'])
+	if got := se.Equity(state, move); got != want {
+		t.Errorf("equity with an empty bag %v, want %v", got, want)
+	}
+}
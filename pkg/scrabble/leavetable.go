@@ -0,0 +1,259 @@
+package scrabble
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxLeaveSize is the largest leave that can be kept after a tile move
+const MaxLeaveSize = RackSize - 1
+
+var ErrInvalidLeaveTable = errors.New("invalid leave table")
+
+// Make sure the leave table implements the LeaveEvaluator interface
+var _ LeaveEvaluator = (*LeaveTable)(nil)
+
+// LeaveTable holds precomputed values for rack leaves of up to
+// MaxLeaveSize tiles, keyed by their letters in alphabetical order.
+//
+// A leave table file has one leave per line, made of the sorted letters
+// of the leave ('*' for blanks) and its value, separated by whitespace:
+//
+//	# comment
+//	*s 31.25
+//	aeq -4.5
+type LeaveTable struct {
+	Values map[string]float64
+	// Fallback values the leaves that are not in the table;
+	// such leaves are worth 0 if it is nil
+	Fallback LeaveEvaluator
+}
+
+// LeaveTrainer accumulates the future point differential of every
+// leave kept during self-play games, so that leave values can be
+// estimated as its average over many games. It is safe for concurrent use.
+type LeaveTrainer struct {
+	mu    sync.Mutex
+	stats map[string]*leaveStat
+	// Sum of all the recorded differentials, used as a baseline
+	total   float64
+	samples int
+	games   int
+}
+
+type leaveStat struct {
+	sum   float64
+	count int
+}
+
+func NewLeaveTable() *LeaveTable {
+	return &LeaveTable{
+		Values: make(map[string]float64),
+	}
+}
+
+// LoadLeaveTable reads a leave table from the file at the given path
+func LoadLeaveTable(path string) (*LeaveTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadLeaveTable(f)
+}
+
+// ReadLeaveTable parses a leave table in the leave table file format
+func ReadLeaveTable(r io.Reader) (*LeaveTable, error) {
+	lt := NewLeaveTable()
+
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanLines)
+
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: expected a leave and a value", ErrInvalidLeaveTable, lineNum)
+		}
+		leave := fields[0]
+		if len([]rune(leave)) > MaxLeaveSize {
+			return nil, fmt.Errorf("%w: line %d: leave %q is too long", ErrInvalidLeaveTable, lineNum, leave)
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidLeaveTable, lineNum, err)
+		}
+		lt.Values[SortLetters(leave)] = value
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return lt, nil
+}
+
+// Save writes the leave table to the file at the given path
+func (lt *LeaveTable) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := lt.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write writes the leave table in the leave table file format, sorted
+// by leave length and then alphabetically
+func (lt *LeaveTable) Write(w io.Writer) error {
+	leaves := make([]string, 0, len(lt.Values))
+	for leave := range lt.Values {
+		leaves = append(leaves, leave)
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		if len(leaves[i]) != len(leaves[j]) {
+			return len(leaves[i]) < len(leaves[j])
+		}
+		return leaves[i] < leaves[j]
+	})
+
+	bw := bufio.NewWriter(w)
+	for _, leave := range leaves {
+		if _, err := fmt.Fprintf(bw, "%s %.3f\n", leave, lt.Values[leave]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// LeaveValue returns the value of the leave from the table, or from
+// the Fallback evaluator if the table does not contain it
+func (lt *LeaveTable) LeaveValue(leave string) float64 {
+	if leave == "" {
+		return 0
+	}
+	if value, ok := lt.Values[SortLetters(leave)]; ok {
+		return value
+	}
+	if lt.Fallback != nil {
+		return lt.Fallback.LeaveValue(leave)
+	}
+	return 0
+}
+
+func NewLeaveTrainer() *LeaveTrainer {
+	return &LeaveTrainer{
+		stats: make(map[string]*leaveStat),
+	}
+}
+
+// AddGame records the leaves of a finished Game. For every tile move or
+// exchange made while tiles remained in the bag, the future point
+// differential of the kept leave is the number of points the player
+// scored for the rest of the game minus the points of the opponent.
+func (t *LeaveTrainer) AddGame(g *Game) {
+	state := g.State()
+	// Scores of each player after each move of the MoveList
+	scores := make([][2]int, len(g.MoveList))
+	var running [2]int
+	for i, item := range g.MoveList {
+		running[i%2] += item.Move.Score(state)
+		scores[i] = running
+	}
+	final := running
+
+	// Replay the bag, from the tiles of the TileSet less those of the
+	// first racks, to know which leaves could still draw tiles
+	inBag := 0
+	for _, count := range g.TileSet.Count {
+		inBag += count
+	}
+	for i := 0; i < 2 && i < len(g.MoveList); i++ {
+		inBag -= len([]rune(g.MoveList[i].RackBefore))
+	}
+	records := make(map[string][]float64)
+	for i, item := range g.MoveList {
+		player := i % 2
+		// The leave is drawn to from the bag as it was before the move
+		canDraw := inBag > 0
+		var leave string
+		switch move := item.Move.(type) {
+		case *TileMove:
+			inBag -= len(move.Covers)
+			if inBag < 0 {
+				inBag = 0
+			}
+			leave = RackLeave(item.RackBefore, move)
+		case *ExchangeMove:
+			leave = RackLeave(item.RackBefore, move)
+		default:
+			continue
+		}
+		if !canDraw || leave == "" || len([]rune(leave)) > MaxLeaveSize {
+			// Nothing can be drawn to this leave, or the player
+			// kept the whole rack
+			continue
+		}
+		own := final[player] - scores[i][player]
+		opp := final[1-player] - scores[i][1-player]
+		records[leave] = append(records[leave], float64(own-opp))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.games++
+	for leave, diffs := range records {
+		stat, ok := t.stats[leave]
+		if !ok {
+			stat = &leaveStat{}
+			t.stats[leave] = stat
+		}
+		for _, diff := range diffs {
+			stat.sum += diff
+			stat.count++
+			t.total += diff
+			t.samples++
+		}
+	}
+}
+
+// Games returns the number of games recorded so far
+func (t *LeaveTrainer) Games() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.games
+}
+
+// Table returns a LeaveTable with the average differential of every leave
+// seen at least minSamples times, relative to the average differential of
+// all leaves
+func (t *LeaveTrainer) Table(minSamples int) *LeaveTable {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lt := NewLeaveTable()
+	if t.samples == 0 {
+		return lt
+	}
+	baseline := t.total / float64(t.samples)
+	for leave, stat := range t.stats {
+		if stat.count < minSamples {
+			continue
+		}
+		lt.Values[leave] = stat.sum/float64(stat.count) - baseline
+	}
+	return lt
+}
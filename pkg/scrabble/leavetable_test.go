@@ -0,0 +1,109 @@
+package scrabble
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadLeaveTable(t *testing.T) {
+	lt, err := ReadLeaveTable(strings.NewReader("# comment\ns* 31.25\n\naeq -4.5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Leaves are keyed by their sorted letters
+	if len(lt.Values) != 2 || lt.Values["*s"] != 31.25 || lt.Values["aeq"] != -4.5 {
+		t.Errorf("got %v", lt.Values)
+	}
+
+	var buf bytes.Buffer
+	if err := lt.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "*s 31.250\naeq -4.500\n"; got != want {
+		t.Errorf("Write gives %q, want %q", got, want)
+	}
+
+	for _, input := range []string{"aeq", "aeq 1 2", "aeq x", "abcdefg 1"} {
+		if _, err := ReadLeaveTable(strings.NewReader(input)); !errors.Is(err, ErrInvalidLeaveTable) {
+			t.Errorf("reading %q: got error %v, want %v", input, err, ErrInvalidLeaveTable)
+		}
+	}
+}
+
+func TestLeaveTableFallback(t *testing.T) {
+	lt := NewLeaveTable()
+	lt.Values["es"] = 5
+	if got := lt.LeaveValue("se"); got != 5 {
+		t.Errorf("LeaveValue(se) = %v, want 5", got)
+	}
+	if got := lt.LeaveValue("q"); got != 0 {
+		t.Errorf("LeaveValue(q) without fallback = %v, want 0", got)
+	}
+	lt.Fallback = &HeuristicLeaves{Tiles: map[rune]float64{'q': -7}}
+	if got := lt.LeaveValue("q"); got != -7 {
+		t.Errorf("LeaveValue(q) with fallback = %v, want -7", got)
+	}
+	if got := lt.LeaveValue(""); got != 0 {
+		t.Errorf("LeaveValue of the empty leave = %v, want 0", got)
+	}
+}
+
+// scoredTileMove returns a TileMove laying the given letters, which
+// is only meant to be recorded in a MoveList, not played
+func scoredTileMove(letters string, score int) *TileMove {
+	covers := make(Covers)
+	for i, letter := range letters {
+		covers[Position{Row: 0, Col: i}] = Cover{Letter: letter, Actual: letter}
+	}
+	return &TileMove{Covers: covers, CachedScore: &score}
+}
+
+func TestLeaveTrainerEmptyingBag(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+	// Twelve moves of seven tiles leave four tiles in the bag
+	for i := 0; i < 12; i++ {
+		g.MoveList = append(g.MoveList, &MoveItem{RackBefore: "zzzzzzz", Move: scoredTileMove("zzzzzzz", 10)})
+	}
+	g.MoveList = append(g.MoveList,
+		// This move empties the bag, drawing four tiles to its leave
+		&MoveItem{RackBefore: "abcdefg", Move: scoredTileMove("abcd", 20)},
+		// Nothing can be drawn to this leave
+		&MoveItem{RackBefore: "hijklmn", Move: scoredTileMove("hijk", 5)},
+	)
+
+	trainer := NewLeaveTrainer()
+	trainer.AddGame(g)
+	lt := trainer.Table(1)
+	if _, ok := lt.Values["efg"]; !ok || len(lt.Values) != 1 {
+		t.Errorf("got leaves %v, want only efg", lt.Values)
+	}
+	if trainer.Games() != 1 {
+		t.Errorf("%d games, want 1", trainer.Games())
+	}
+}
+
+func TestLeaveTrainerSmallTileSet(t *testing.T) {
+	ts := &TileSet{Count: map[rune]int{'a': 10, 'b': 10}, Values: map[rune]int{'a': 1, 'b': 3}}
+	g := NewGame(ts, newTestDAWG())
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+	// The first racks leave six tiles in the bag, emptied by two moves
+	g.MoveList = append(g.MoveList,
+		&MoveItem{RackBefore: "aaaaaaa", Move: scoredTileMove("aaa", 10)},
+		&MoveItem{RackBefore: "bbbbbbb", Move: scoredTileMove("bbb", 12)},
+		&MoveItem{RackBefore: "aaaaaaa", Move: scoredTileMove("aa", 4)},
+	)
+
+	trainer := NewLeaveTrainer()
+	trainer.AddGame(g)
+	lt := trainer.Table(1)
+	_, okA := lt.Values["aaaa"]
+	_, okB := lt.Values["bbbb"]
+	if !okA || !okB || len(lt.Values) != 2 {
+		t.Errorf("got leaves %v, want only aaaa and bbbb", lt.Values)
+	}
+}
@@ -0,0 +1,251 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultSimCandidates = 10
+	DefaultSimIterations = 100
+)
+
+// MonteCarlo strategy takes the best candidate moves according to a
+// static evaluation and, for each of them, simulates random opponent
+// racks drawn from the unseen tiles. Each simulation plays out the
+// opponent's response and our own next move with the static strategy,
+// and the candidate with the best average outcome is picked.
+type MonteCarlo struct {
+	// Candidates is the number of top moves that are simulated;
+	// DefaultSimCandidates is used if zero
+	Candidates int
+	// Iterations is the number of simulations for each candidate;
+	// DefaultSimIterations is used if zero
+	Iterations int
+	// Budget is the maximum time spent simulating, no limit if zero.
+	// Candidates are ranked on the simulations completed in time.
+	Budget time.Duration
+	// ByWinRate ranks the candidates by win percentage rather
+	// than by average spread
+	ByWinRate bool
+	// Static selects the candidates and plays the simulated
+	// responses; a StaticEval with default leaves is used if nil
+	Static *StaticEval
+}
+
+// SimResult holds the outcome of the simulations of a candidate move
+type SimResult struct {
+	Move Move
+	// Equity is the static equity of the move
+	Equity float64
+	// Spread is the average point differential after the move, the
+	// opponent's response and our next move
+	Spread float64
+	// WinRate is the fraction of simulations where we would be ahead
+	// at the end of the simulation, draws counting as half a win
+	WinRate    float64
+	Iterations int
+}
+
+// PickMove for MonteCarlo selects the candidate with the best simulated
+// outcome, or a pass move as a last resort
+func (mc *MonteCarlo) PickMove(state *GameState, moves []Move) Move {
+	results := mc.Simulate(state, moves)
+	if len(results) == 0 {
+		// No tile move and exchange forbidden: Return a pass move
+		return NewPassMove()
+	}
+	return results[0].Move
+}
+
+// Simulate runs the simulations of the top candidates and returns
+// their results from the best to the worst
+func (mc *MonteCarlo) Simulate(state *GameState, moves []Move) []*SimResult {
+	static := mc.static()
+	candidates := static.Evaluate(state, moves)
+	numCandidates := mc.Candidates
+	if numCandidates <= 0 {
+		numCandidates = DefaultSimCandidates
+	}
+	if len(candidates) > numCandidates {
+		candidates = candidates[:numCandidates]
+	}
+	iterations := mc.Iterations
+	if iterations <= 0 {
+		iterations = DefaultSimIterations
+	}
+	var deadline time.Time
+	if mc.Budget > 0 {
+		deadline = time.Now().Add(mc.Budget)
+	}
+
+	unseen := state.UnseenLetters()
+	results := make([]*SimResult, len(candidates))
+	spreads := make([]float64, len(candidates))
+	wins := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		results[i] = &SimResult{Move: candidate.Move, Equity: candidate.Equity}
+	}
+	// Simulate in rounds, one goroutine per candidate, so that all the
+	// candidates have the same number of iterations when time runs out
+	doneChan := make(chan int, len(candidates))
+	for round := 0; round < iterations; round++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+		for i := range candidates {
+			go func(i int) {
+				spread := mc.simulateOnce(state, results[i].Move, unseen)
+				spreads[i] += spread
+				switch final := float64(state.Spread) + spread; {
+				case final > 0:
+					wins[i]++
+				case final == 0:
+					wins[i] += 0.5
+				}
+				doneChan <- i
+			}(i)
+		}
+		for range candidates {
+			results[<-doneChan].Iterations++
+		}
+	}
+	for i, result := range results {
+		if result.Iterations > 0 {
+			result.Spread = spreads[i] / float64(result.Iterations)
+			result.WinRate = wins[i] / float64(result.Iterations)
+		}
+	}
+
+	sortSimResults(results, mc.ByWinRate)
+	return results
+}
+
+// sortSimResults sorts simulation results from the best to the worst,
+// by average spread or by win percentage
+func sortSimResults(results []*SimResult, byWinRate bool) {
+	sort.SliceStable(results, func(i, j int) bool {
+		ri, rj := results[i], results[j]
+		if ri.Iterations == 0 {
+			// No simulation could be run in time: rank
+			// the candidates by their static equity
+			return ri.Equity > rj.Equity
+		}
+		if byWinRate && ri.WinRate != rj.WinRate {
+			return ri.WinRate > rj.WinRate
+		}
+		return ri.Spread > rj.Spread
+	})
+}
+
+func (mc *MonteCarlo) static() *StaticEval {
+	if mc.Static == nil {
+		return &StaticEval{}
+	}
+	return mc.Static
+}
+
+// simulateOnce plays the move, a response from an opponent holding a
+// random rack, and our next move, and returns the resulting spread
+func (mc *MonteCarlo) simulateOnce(state *GameState, move Move, unseen string) float64 {
+	static := mc.static()
+	board := state.cloneBoardState()
+	bag := newBagOf(unseen, state.TileSet)
+	// Deal the opponent a random rack from the unseen tiles:
+	// the remaining ones are in the bag
+	opp := &Rack{Tiles: make([]*Tile, 0, RackSize)}
+	for i := state.OpponentRackSize(); i > 0; i-- {
+		tile, err := bag.DrawTile()
+		if err != nil {
+			break
+		}
+		opp.Tiles = append(opp.Tiles, tile)
+	}
+	rack := newRackOf(state.Rack.AsString(), state.TileSet)
+
+	spread := float64(move.Score(state))
+	if simulateApply(board, rack, bag, move, state.TileSet) {
+		// We went out: the game is over
+		return spread + float64(2*rackLettersValue(opp.AsString(), state.TileSet))
+	}
+
+	return spread + simulateResponses(static, state, board, rack, opp, bag)
+}
+
+// simulateResponses plays the opponent's response and our next move with
+// the static strategy, and returns the resulting spread for us
+func simulateResponses(static *StaticEval, state *GameState, board *BoardState, rack, opp *Rack, bag *Bag) float64 {
+	// The opponent's response
+	oppState := simulatedState(state, board, opp, bag)
+	reply := static.PickMove(oppState, oppState.GenerateMoves())
+	spread := -float64(reply.Score(oppState))
+	if simulateApply(board, opp, bag, reply, state.TileSet) {
+		// The opponent went out: the game is over
+		return spread - float64(2*rackLettersValue(rack.AsString(), state.TileSet))
+	}
+
+	// Our next move, valued with its equity to account for the leave
+	ownState := simulatedState(state, board, rack, bag)
+	next := static.PickMove(ownState, ownState.GenerateMoves())
+	return spread + static.Equity(ownState, next)
+}
+
+func simulatedState(state *GameState, board *BoardState, rack *Rack, bag *Bag) *GameState {
+	return &GameState{
+		DAWG:            state.DAWG,
+		TileSet:         state.TileSet,
+		Board:           board.Board,
+		BoardState:      board,
+		Rack:            rack,
+		ExchangeAllowed: bag.ExchangeAllowed(),
+		BagTileCount:    bag.TileCount(),
+	}
+}
+
+// simulateApply applies a move to a board, rack and bag that are not part
+// of a Game, and returns true if the player went out, ending the game
+func simulateApply(board *BoardState, rack *Rack, bag *Bag, move Move, tileSet *TileSet) bool {
+	switch m := move.(type) {
+	case *TileMove:
+		for _, cover := range m.Covers {
+			_ = rack.Remove(cover.Letter)
+		}
+		if err := board.PlaceCovers(m.Covers, tileSet); err != nil {
+			// Should not happen with generated moves
+			return false
+		}
+		rack.Fill(bag)
+		return rack.IsEmpty()
+	case *ExchangeMove:
+		tiles := make([]*Tile, 0, RackSize)
+		for _, letter := range m.Letters {
+			tile, err := rack.GetTile(letter)
+			if err != nil {
+				continue
+			}
+			_ = rack.Remove(letter)
+			tiles = append(tiles, tile)
+		}
+		rack.Fill(bag)
+		for _, tile := range tiles {
+			bag.ReturnTile(tile)
+		}
+	}
+	return false
+}
+
+// newRackOf returns a Rack holding fresh tiles for the given letters
+func newRackOf(letters string, tileSet *TileSet) *Rack {
+	rack := &Rack{Tiles: make([]*Tile, 0, RackSize)}
+	for _, letter := range letters {
+		rack.Tiles = append(rack.Tiles, &Tile{Letter: letter, Value: tileSet.Values[letter]})
+	}
+	return rack
+}
+
+// String returns a string description of the SimResult
+func (sr *SimResult) String() string {
+	return fmt.Sprintf("%v Equity: %.1f Spread: %.1f Win: %.1f%% (%d iterations)",
+		sr.Move, sr.Equity, sr.Spread, 100*sr.WinRate, sr.Iterations)
+}
@@ -0,0 +1,87 @@
+package scrabble
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonteCarloSimulate(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+	before := g.Board.String()
+
+	mc := &MonteCarlo{Candidates: 3, Iterations: 4}
+	results := mc.Simulate(state, state.GenerateMoves())
+	if len(results) != 3 {
+		t.Fatalf("%d results, want 3", len(results))
+	}
+	for i, result := range results {
+		if result.Iterations != 4 {
+			t.Errorf("%v: %d iterations, want 4", result, result.Iterations)
+		}
+		if i > 0 && result.Spread > results[i-1].Spread {
+			t.Errorf("%v is ranked after %v", result, results[i-1])
+		}
+	}
+	if g.Board.String() != before {
+		t.Errorf("the simulations changed the board:\n%v", g.Board)
+	}
+}
+
+func TestMonteCarloBudget(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+
+	mc := &MonteCarlo{Iterations: 1000000, Budget: 50 * time.Millisecond}
+	start := time.Now()
+	move := mc.PickMove(state, state.GenerateMoves())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("simulating took %v with a budget of %v", elapsed, mc.Budget)
+	}
+	if _, ok := move.(*TileMove); !ok {
+		t.Errorf("got %v, want a tile move", move)
+	}
+}
+
+func TestMonteCarloNoMove(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	state := &GameState{
+		DAWG:    g.DAWG,
+		TileSet: g.TileSet,
+		Board:   g.Board,
+		Rack:    newRackOf("qq", g.TileSet),
+	}
+	mc := &MonteCarlo{Iterations: 1}
+	if move := mc.PickMove(state, state.GenerateMoves()); !isPassMove(move) {
+		t.Errorf("got %v, want a pass", move)
+	}
+}
+
+func TestSortSimResults(t *testing.T) {
+	results := []*SimResult{
+		{Equity: 1, Spread: 10, WinRate: 0.4, Iterations: 5},
+		{Equity: 2, Spread: 5, WinRate: 0.6, Iterations: 5},
+	}
+	sortSimResults(results, false)
+	if results[0].Spread != 10 {
+		t.Errorf("by spread, got %v first", results[0])
+	}
+	sortSimResults(results, true)
+	if results[0].WinRate != 0.6 {
+		t.Errorf("by win rate, got %v first", results[0])
+	}
+
+	// Without simulations, the static equity decides
+	results = []*SimResult{{Equity: 1}, {Equity: 2}}
+	sortSimResults(results, false)
+	if results[0].Equity != 2 {
+		t.Errorf("without iterations, got %v first", results[0])
+	}
+}
+
+func isPassMove(move Move) bool {
+	_, ok := move.(*PassMove)
+	return ok
+}
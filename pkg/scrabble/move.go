@@ -122,11 +122,12 @@ func (move *TileMove) Init(b *Board, covers Covers, validateWords bool) {
 		direction = DirectionBellow
 		reverse = DirectionAbove
 	}
-	sq := b.GetSquare(move.Start)
-	if sq == nil {
+	if !move.Start.InBounds() || !move.End.InBounds() {
+		// No covers, or covers off the board
 		move.Word = IllegalMoveWord
 		return
 	}
+	sq := b.GetSquare(move.Start)
 	// Start with any left prefix that is being extended
 	word := b.WordFragment(move.Start, reverse)
 	// Next, traverse the covering line from top left to bottom right
@@ -140,7 +141,7 @@ func (move *TileMove) Init(b *Board, covers Covers, validateWords bool) {
 				move.Word = IllegalMoveWord
 				return
 			}
-			word += string(sq.Tile.Letter)
+			word += string(unicode.ToLower(sq.Tile.Letter))
 		}
 		if sq.Position.Row == bottom && sq.Position.Col == right {
 			// This was the last tile laid down in the move:
@@ -159,6 +160,19 @@ func (move *TileMove) Init(b *Board, covers Covers, validateWords bool) {
 	move.Word = word
 }
 
+// Words returns the main word and the cross words formed by the
+// TileMove on the Board it is about to be applied to
+func (move *TileMove) Words(b *Board) []string {
+	words := []string{strings.ToLower(move.Word)}
+	for pos, cover := range move.Covers {
+		left, right := b.CrossWordFragments(pos, !move.Horizontal)
+		if len(left) > 0 || len(right) > 0 {
+			words = append(words, strings.ToLower(left+string(cover.Actual)+right))
+		}
+	}
+	return words
+}
+
 // IsValid returns true if the TileMove is valid in the current Game
 func (move *TileMove) IsValid(game *Game) bool {
 	// Check the validity of the move
@@ -226,8 +240,8 @@ func (move *TileMove) IsValid(game *Game) bool {
 		return true
 	}
 
-	// Check if word is valid
-	if move.Word == IllegalMoveWord || move.Word == "" {
+	// Check if word is valid, a word being at least made of two letters
+	if move.Word == IllegalMoveWord || len([]rune(move.Word)) < 2 {
 		return false
 	}
 	if !game.DAWG.IsWord(move.Word) {
@@ -257,16 +271,16 @@ func (move *TileMove) Apply(game *Game) error {
 		)
 
 		tile, err = rack.GetTile(cover.Letter)
-		if cover.Letter == '*' {
-			// It is a blank tile, put the letter to uppercase on the tile
-			tile.Letter = unicode.ToUpper(cover.Actual)
-		}
-
 		if err != nil {
 			// Should not happen
 			return err
 		}
 
+		if cover.Letter == '*' {
+			// It is a blank tile, put the letter to uppercase on the tile
+			tile.Letter = unicode.ToUpper(cover.Actual)
+		}
+
 		err = game.PlayTile(tile, pos, rack)
 		if err != nil {
 			// Should not happen
@@ -358,7 +372,11 @@ func (move *TileMove) Score(state *GameState) int {
 func (move *TileMove) String() string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("Start%v End%v Word: %s Score: %d", move.Start, move.End, move.Word, *move.CachedScore))
+	sb.WriteString(fmt.Sprintf("Start%v End%v Word: %s", move.Start, move.End, move.Word))
+	if move.CachedScore != nil {
+		// The score is only known once the move has been scored
+		sb.WriteString(fmt.Sprintf(" Score: %d", *move.CachedScore))
+	}
 
 	return sb.String()
 }
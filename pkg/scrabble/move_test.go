@@ -0,0 +1,294 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+// testWords is a small dictionary, so that tests do not depend on the assets
+var testWords = []string{
+	"a", "ab", "abet", "act", "acts", "at", "ate", "bat", "bats", "be", "beast",
+	"beat", "beats", "best", "bet", "bets", "cab", "cabs", "case", "cast",
+	"cat", "cats", "east", "eat", "eats", "es", "et", "sat", "scab", "scat", "scats",
+	"sea", "seat", "set", "sta", "stab", "tab", "tabs", "tea", "teas", "ta",
+}
+
+func newTestDAWG() *DAWG {
+	return NewDawg(&Dictionary{Words: testWords})
+}
+
+// newTestGame returns a game on which "cats" and "tab" were played
+func newTestGame(t testing.TB) *Game {
+	t.Helper()
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	g.Players[0] = NewPlayer("A", g.Bag)
+	g.Players[1] = NewPlayer("B", g.Bag)
+	for _, covers := range []Covers{
+		{
+			{Row: 7, Col: 6}: {Letter: 'c', Actual: 'c'},
+			{Row: 7, Col: 7}: {Letter: 'a', Actual: 'a'},
+			{Row: 7, Col: 8}: {Letter: 't', Actual: 't'},
+			{Row: 7, Col: 9}: {Letter: 's', Actual: 's'},
+		},
+		{
+			{Row: 8, Col: 8}: {Letter: '*', Actual: 'a'},
+			{Row: 9, Col: 8}: {Letter: 'b', Actual: 'b'},
+		},
+	} {
+		if err := g.BoardState.PlaceCovers(covers, g.TileSet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// coversFromBytes decodes fuzzer input into covers, three bytes per
+// cover, which may be off the board or on occupied squares
+func coversFromBytes(data []byte) Covers {
+	covers := make(Covers)
+	for i := 0; i+2 < len(data); i += 3 {
+		pos := Position{
+			Row: int(data[i])%(BoardSize+2) - 1,
+			Col: int(data[i+1])%(BoardSize+2) - 1,
+		}
+		actual := rune(data[i+2] & 0x7f)
+		if actual < 'a' || actual > 'z' {
+			actual = 'a' + actual%26
+		}
+		cover := Cover{Letter: actual, Actual: actual}
+		if data[i+2]&0x80 != 0 {
+			cover.Letter = '*'
+		}
+		covers[pos] = cover
+	}
+	return covers
+}
+
+// isConnected returns true if every tile on the board can be reached
+// from the center square through adjacent tiles
+func isConnected(b *Board) bool {
+	center := b.GetSquare(Position{Row: BoardCenter, Col: BoardCenter})
+	if center.Tile == nil {
+		return b.String() == NewBoard().String()
+	}
+	seen := map[Position]bool{center.Position: true}
+	queue := []*Square{center}
+	for len(queue) > 0 {
+		sq := queue[0]
+		queue = queue[1:]
+		for _, adj := range b.Adjacents[sq.Position.Row][sq.Position.Col] {
+			if adj != nil && adj.Tile != nil && !seen[adj.Position] {
+				seen[adj.Position] = true
+				queue = append(queue, adj)
+			}
+		}
+	}
+	for row := 0; row < BoardSize; row++ {
+		for col := 0; col < BoardSize; col++ {
+			if b.Squares[row][col].Tile != nil && !seen[Position{Row: row, Col: col}] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func FuzzTileMove(f *testing.F) {
+	// Rows and columns are shifted by one, to also get positions
+	// off the board: tabs, scats and catsa
+	f.Add([]byte{11, 9, 's'})
+	f.Add([]byte{8, 6, 's'})
+	f.Add([]byte{8, 11, 'a'})
+	f.Add([]byte{7, 10, 'e' | 0x80, 6, 10, 'b', 5, 10, 'a'})
+	f.Add([]byte{10, 10, 'e', 10, 11, 'a', 10, 12, 't'})
+	f.Add([]byte{0, 0, 'a', 16, 16, 'b'})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := newTestGame(t)
+		covers := coversFromBytes(data)
+
+		move := NewTileMove(g.Board, covers)
+		if !move.IsValid(g) {
+			return
+		}
+		if !NewUncheckedTileMove(g.Board, covers).IsValid(g) {
+			t.Fatalf("%v is valid, but not without validating its words", move)
+		}
+		state := g.State()
+		score := move.Score(state)
+		if score < 0 {
+			t.Fatalf("%v has a negative score", move)
+		}
+
+		// Play the move from a rack holding its tiles
+		before := g.Board.Clone()
+		rack := ""
+		for _, cover := range covers {
+			rack += string(cover.Letter)
+		}
+		g.PlayerToMove().Rack = newRackOf(rack, g.TileSet)
+		if err := move.Apply(g); err != nil {
+			t.Fatal(err)
+		}
+		if !isConnected(g.Board) {
+			t.Fatalf("%v leaves the board disconnected:\n%v", move, g.Board)
+		}
+		for _, word := range move.Words(before) {
+			if !g.DAWG.IsWord(word) {
+				t.Fatalf("%v forms the invalid word %q", move, word)
+			}
+		}
+
+		// Undoing the move restores the board and its BoardState
+		if err := g.BoardState.RemoveCovers(covers); err != nil {
+			t.Fatal(err)
+		}
+		if g.Board.String() != before.String() {
+			t.Fatalf("undoing %v gives\n%v\ninstead of\n%v", move, g.Board, before)
+		}
+		fresh := NewBoardState(g.Board, g.DAWG)
+		if fresh.anchors != g.BoardState.anchors || fresh.crossChecks != g.BoardState.crossChecks {
+			t.Fatalf("undoing %v does not restore the BoardState", move)
+		}
+	})
+}
+
+func TestTileMoveInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		covers Covers
+	}{
+		{"no covers", Covers{}},
+		{"off the board", Covers{{Row: 7, Col: 15}: {Letter: 'a', Actual: 'a'}}},
+		{"occupied square", Covers{{Row: 7, Col: 7}: {Letter: 'a', Actual: 'a'}}},
+		{"not connected", Covers{
+			{Row: 0, Col: 0}: {Letter: 'a', Actual: 'a'},
+			{Row: 0, Col: 1}: {Letter: 't', Actual: 't'},
+		}},
+		{"gap", Covers{
+			{Row: 6, Col: 9}: {Letter: 'e', Actual: 'e'},
+			{Row: 4, Col: 9}: {Letter: 'b', Actual: 'b'},
+		}},
+		{"not a word", Covers{{Row: 7, Col: 10}: {Letter: 'a', Actual: 'a'}}},
+		{"invalid cross word", Covers{
+			{Row: 8, Col: 9}:  {Letter: 'a', Actual: 'a'},
+			{Row: 8, Col: 10}: {Letter: 't', Actual: 't'},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t)
+			if move := NewTileMove(g.Board, tt.covers); move.IsValid(g) {
+				t.Errorf("%v should not be valid", move)
+			}
+		})
+	}
+}
+
+func TestTileMoveSingleLetter(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	center := Position{Row: BoardCenter, Col: BoardCenter}
+	move := NewTileMove(g.Board, Covers{center: {Letter: 'a', Actual: 'a'}})
+	if move.IsValid(g) {
+		t.Errorf("%v should not be valid, words being at least two letters long", move)
+	}
+}
+
+func TestTileMoveScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		covers Covers
+		word   string
+		score  int
+	}{
+		// s on a plain square, next to c(3) a(1) t(1) s(1)
+		{"hook", Covers{{Row: 7, Col: 5}: {Letter: 's', Actual: 's'}}, "scats", 7},
+		// The blank a below the t is worth nothing
+		{"blank on the board", Covers{{Row: 10, Col: 8}: {Letter: 's', Actual: 's'}}, "tabs", 5},
+		{"blank from the rack", Covers{
+			{Row: 10, Col: 8}: {Letter: '*', Actual: 's'},
+		}, "tabs", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t)
+			move := NewTileMove(g.Board, tt.covers)
+			if !move.IsValid(g) {
+				t.Fatalf("%v should be valid", move)
+			}
+			if move.Word != tt.word {
+				t.Errorf("got word %q, want %q", move.Word, tt.word)
+			}
+			if score := move.Score(g.State()); score != tt.score {
+				t.Errorf("got score %d, want %d", score, tt.score)
+			}
+		})
+	}
+}
+
+func TestGenerateMovesMatchesReference(t *testing.T) {
+	for _, rack := range []string{"aestb", "*aest", "cab", "**", "etabsac"} {
+		t.Run(strings.ReplaceAll(rack, "*", "blank"), func(t *testing.T) {
+			g := newTestGame(t)
+			state := g.State()
+			state.Rack = newRackOf(rack, g.TileSet)
+			if diff := DiffMoveGenerators(state); !diff.OK() {
+				t.Errorf("%v", diff)
+			}
+			// Same without the incremental BoardState
+			state.BoardState = nil
+			if diff := DiffMoveGenerators(state); !diff.OK() {
+				t.Errorf("without BoardState: %v", diff)
+			}
+		})
+	}
+}
+
+func TestTileMoveNotation(t *testing.T) {
+	tests := []struct {
+		coordinate, word string
+		notation         string
+		err              error
+	}{
+		// The blank a of tab is on the board
+		{"I8", "tabs", "I8 tAbs", nil},
+		{"i8", "tAbS", "I8 tAbS", nil},
+		{"8F", "Scats", "8F Scats", nil},
+		{"11H", "es", "11H es", nil},
+		{"8G", "cats", "", ErrInvalidNotation},
+		{"8G", "dogs", "", ErrInvalidNotation},
+		{"8N", "abc", "", ErrInvalidNotation},
+		{"16A", "at", "", ErrInvalidCoordinate},
+		{"P1", "at", "", ErrInvalidCoordinate},
+		{"H", "at", "", ErrInvalidCoordinate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.coordinate+" "+tt.word, func(t *testing.T) {
+			g := newTestGame(t)
+			move, err := ParseTileMove(g.Board, tt.coordinate, tt.word)
+			if err != tt.err {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if !move.IsValid(g) {
+				t.Errorf("%v should be valid", move)
+			}
+			if notation := move.Notation(g.Board); notation != tt.notation {
+				t.Errorf("got notation %q, want %q", notation, tt.notation)
+			}
+		})
+	}
+}
+
+func TestTileMoveApplyMissingTile(t *testing.T) {
+	g := newTestGame(t)
+	// The move needs a blank, which is not on the rack
+	g.PlayerToMove().Rack = newRackOf("e", g.TileSet)
+	move := NewTileMove(g.Board, Covers{{Row: 10, Col: 8}: {Letter: '*', Actual: 's'}})
+	if err := move.Apply(g); err == nil {
+		t.Errorf("%v should not be applied without a blank on the rack", move)
+	}
+}
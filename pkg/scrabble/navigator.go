@@ -2,6 +2,7 @@ package scrabble
 
 import (
 	"strings"
+	"unicode"
 )
 
 type Match int
@@ -20,6 +21,8 @@ var (
 	_ Navigator = (*LeftPermutationNavigator)(nil)
 	_ Navigator = (*MatchNavigator)(nil)
 	_ Navigator = (*FindNavigator)(nil)
+	_ Navigator = (*AnagramNavigator)(nil)
+	_ Navigator = (*PatternNavigator)(nil)
 )
 
 // Navigator is an interface that describes behaviors that control the
@@ -178,8 +181,9 @@ func (ean *ExtendAfterNavigator) Init(axis *Axis, anchor int, rack string) {
 func (ean *ExtendAfterNavigator) check(letter rune) Match {
 	tileAtSq := ean.axis.squares[ean.index].Tile
 	if tileAtSq != nil {
-		// There is a tile in the square: must match it exactly
-		if letter == tileAtSq.Letter {
+		// There is a tile in the square: must match it exactly,
+		// blank tiles having their letter in uppercase
+		if letter == unicode.ToLower(tileAtSq.Letter) {
 			// Matches, from the board
 			return MatchBoardTile
 		}
@@ -242,6 +246,10 @@ func (ean *ExtendAfterNavigator) IsAccepting() bool {
 		// Gone off the board edge
 		return false
 	}
+	if ean.axis.canceled() {
+		// The move generation has been stopped
+		return false
+	}
 	// Otherwise, continue while we have something on the rack
 	// or we're at an occupied square
 	return len(ean.rack) > 0 || ean.axis.squares[ean.index].Tile != nil
@@ -299,6 +307,18 @@ func (ean *ExtendAfterNavigator) Accept(matched string, isWord bool, ns *navStat
 	covers := make(Covers)
 	// Calculate the starting index within the axis
 	start := ean.index - len(runes)
+	if !ean.axis.horizontal && ean.axis.isHorizontalSingle(start, len(runes)) {
+		// This single tile move is also found on the horizontal axis
+		return
+	}
+	var score int
+	if ean.axis.topK > 0 {
+		// Only build the move if it is among the best ones so far
+		score = ean.axis.scoreMatch(start, runes)
+		if !ean.axis.best.wants(score, ean.axis.topK) {
+			return
+		}
+	}
 	// The original rack
 	rack := ean.axis.rackString
 	for i, actualLetter := range runes {
@@ -317,6 +337,16 @@ func (ean *ExtendAfterNavigator) Accept(matched string, isWord bool, ns *navStat
 	}
 	// No need to validate robot-generated tile moves
 	tileMove := NewUncheckedTileMove(ean.axis.state.Board, covers)
+	if ean.axis.topK > 0 {
+		tileMove.CachedScore = &score
+		ean.axis.best.add(tileMove, ean.axis.topK)
+		return
+	}
+	if ean.axis.emit != nil {
+		// The moves are streamed rather than collected
+		ean.axis.emit(tileMove)
+		return
+	}
 	ean.moves = append(ean.moves, tileMove)
 }
 
@@ -514,7 +544,7 @@ func (nav *Navigation) Go(d *DAWG, navigator Navigator) {
 // enumerating through outgoing edges until the navigator is
 // satisfied
 func (nav *Navigation) FromNode(n *Node, matched string) {
-	iter := nav.DAWG.iterNodeCache.iterNode(n)
+	iter := nav.DAWG.iterNode(n)
 	for i := 0; i < len(iter); i++ {
 		ns := &iter[i]
 		if nav.navigator.PushEdge(ns.prefix) {
@@ -575,7 +605,9 @@ func (nav *Navigation) FromEdge(ns *navState, matched string) {
 func (nav *Navigation) Resume(d *DAWG, navigator Navigator, ns *navState, matched string) {
 	nav.DAWG = d
 	nav.navigator = navigator
-	if navigator.IsAccepting() {
-		nav.FromEdge(ns, matched)
+	if ns.nextNode != nil && navigator.IsAccepting() {
+		// The edge of the saved state has already been matched:
+		// continue from the node it leads to
+		nav.FromNode(ns.nextNode, matched)
 	}
 }
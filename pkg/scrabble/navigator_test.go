@@ -0,0 +1,44 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMovesFromLeftParts(t *testing.T) {
+	// On the empty board, every move but the ones starting on the center
+	// square is found by resuming the navigation of a left part
+	for _, rack := range []string{"tab", "aestb", "*at", "etabsac"} {
+		t.Run(strings.ReplaceAll(rack, "*", "blank"), func(t *testing.T) {
+			g := NewGame(DefaultTileSet, newTestDAWG())
+			state := &GameState{
+				DAWG:       g.DAWG,
+				TileSet:    g.TileSet,
+				Board:      g.Board,
+				Rack:       newRackOf(rack, g.TileSet),
+				BoardState: g.BoardState,
+			}
+			if diff := DiffMoveGenerators(state); !diff.OK() {
+				t.Errorf("%v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateMovesThroughBlank(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("st", g.TileSet)
+	found := map[string]bool{}
+	for _, move := range state.GenerateMoves() {
+		if tileMove, ok := move.(*TileMove); ok {
+			found[tileMove.Word] = true
+		}
+	}
+	// tabs extends the blank a of tab, and ta is laid before it
+	for _, word := range []string{"tabs", "ta"} {
+		if !found[word] {
+			t.Errorf("%q was not generated", word)
+		}
+	}
+}
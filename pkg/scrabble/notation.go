@@ -0,0 +1,122 @@
+package scrabble
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
+	ErrInvalidNotation   = errors.New("word does not fit the board at that coordinate")
+)
+
+// Coordinate returns the coordinate of the Position in the usual
+// notation: the row number then the column letter for a horizontal
+// word, e.g. 8H, or the column letter then the row number for a
+// vertical word, e.g. H8. Rows are numbered from 1.
+func (p Position) Coordinate(horizontal bool) string {
+	row := strconv.Itoa(p.Row + 1)
+	col := string(rune('A' + p.Col))
+	if horizontal {
+		return row + col
+	}
+	return col + row
+}
+
+// ParseCoordinate parses a coordinate such as 8H or H8, see Coordinate
+func ParseCoordinate(coordinate string) (pos Position, horizontal bool, err error) {
+	coordinate = strings.ToUpper(strings.TrimSpace(coordinate))
+	if len(coordinate) < 2 {
+		return Position{}, false, ErrInvalidCoordinate
+	}
+	var row, col string
+	if first := rune(coordinate[0]); first >= 'A' && first <= 'Z' {
+		col, row = coordinate[:1], coordinate[1:]
+	} else {
+		row, col = coordinate[:len(coordinate)-1], coordinate[len(coordinate)-1:]
+		horizontal = true
+	}
+	r, err := strconv.Atoi(row)
+	if err != nil {
+		return Position{}, false, ErrInvalidCoordinate
+	}
+	pos = Position{Row: r - 1, Col: int(col[0] - 'A')}
+	if !pos.InBounds() {
+		return Position{}, false, ErrInvalidCoordinate
+	}
+	return pos, horizontal, nil
+}
+
+// ParseTileMove returns the TileMove laying the given word at the given
+// coordinate, see Coordinate. The word includes the letters already on
+// the board, which it must match; the other letters are covers, made of
+// blank tiles when they are uppercase. The move is not validated.
+func ParseTileMove(b *Board, coordinate, word string) (*TileMove, error) {
+	pos, horizontal, err := ParseCoordinate(coordinate)
+	if err != nil {
+		return nil, err
+	}
+	covers := make(Covers)
+	for _, letter := range word {
+		if !pos.InBounds() || !unicode.IsLetter(letter) {
+			return nil, ErrInvalidNotation
+		}
+		if tile := b.GetSquare(pos).Tile; tile != nil {
+			if unicode.ToLower(tile.Letter) != unicode.ToLower(letter) {
+				return nil, ErrInvalidNotation
+			}
+		} else if unicode.IsUpper(letter) {
+			covers[pos] = Cover{Letter: '*', Actual: unicode.ToLower(letter)}
+		} else {
+			covers[pos] = Cover{Letter: letter, Actual: letter}
+		}
+		if horizontal {
+			pos.Col++
+		} else {
+			pos.Row++
+		}
+	}
+	if len(covers) == 0 {
+		return nil, ErrInvalidNotation
+	}
+	return NewTileMove(b, covers), nil
+}
+
+// Notation returns the TileMove in the usual notation, e.g. 8H caTs:
+// the coordinate of the first letter of the main word, then the word
+// with its blank tiles in uppercase. It may be called on the Board
+// before or after the move is applied.
+func (move *TileMove) Notation(b *Board) string {
+	direction, reverse := DirectionRight, DirectionLeft
+	if !move.Horizontal {
+		direction, reverse = DirectionBellow, DirectionAbove
+	}
+	// Start with any prefix already on the board
+	start := b.GetSquare(move.Start)
+	for {
+		prev := b.Adjacents[start.Position.Row][start.Position.Col][reverse]
+		if prev == nil || prev.Tile == nil {
+			break
+		}
+		start = prev
+	}
+
+	var sb strings.Builder
+	for sq := start; sq != nil; sq = b.Adjacents[sq.Position.Row][sq.Position.Col][direction] {
+		if cover, ok := move.Covers[sq.Position]; ok {
+			if cover.Letter == '*' {
+				sb.WriteRune(unicode.ToUpper(cover.Actual))
+			} else {
+				sb.WriteRune(cover.Actual)
+			}
+		} else if sq.Tile != nil {
+			sb.WriteRune(sq.Tile.Letter)
+		} else {
+			break
+		}
+	}
+	return fmt.Sprintf("%s %s", start.Position.Coordinate(move.Horizontal), sb.String())
+}
@@ -0,0 +1,339 @@
+package scrabble
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	// PreEndgameMaxBag is the largest number of tiles in the
+	// bag for which the pre-endgame search is used
+	PreEndgameMaxBag = RackSize
+	// DefaultPreEndgameScenarios is the default number of bag draws
+	// evaluated for each candidate move
+	DefaultPreEndgameScenarios = 100
+	// preEndgameExtraCandidates is the number of bag-emptying moves and
+	// of small exchanges added to the candidates picked by equity
+	preEndgameExtraCandidates = 3
+	// smallExchangeSize is the largest exchange that is always
+	// considered in the pre-endgame
+	smallExchangeSize = 2
+)
+
+// Make sure the pre-endgame implements the Strategy interface
+var _ Strategy = (*PreEndgame)(nil)
+
+// PreEndgame strategy searches the positions with 1 to PreEndgameMaxBag
+// tiles left in the bag. The unseen tiles are known exactly, so instead
+// of drawing random racks, it enumerates the possible splits of the unseen
+// tiles between the opponent's rack and the bag, and the tiles drawn after
+// each candidate move. Positions where the bag is emptied are solved with
+// the endgame solver, if any, and the others are played out with the
+// static strategy. Besides the best moves by equity, the candidates always
+// include the best moves that empty the bag and the best small exchanges.
+type PreEndgame struct {
+	// Candidates is the number of top moves by equity that are
+	// evaluated; DefaultSimCandidates is used if zero
+	Candidates int
+	// Scenarios is the largest number of bag draws evaluated for each
+	// candidate; when there are more, that many are picked at random.
+	// DefaultPreEndgameScenarios is used if zero
+	Scenarios int
+	// Budget is the maximum time spent searching, the endgames it
+	// solves included, no limit if zero
+	Budget time.Duration
+	// ByWinRate ranks the candidates by win percentage rather
+	// than by average spread
+	ByWinRate bool
+	// Static selects the candidates and plays the responses when the
+	// bag is not emptied; a StaticEval with default leaves is used if nil
+	Static *StaticEval
+	// Endgame solves the positions where the bag has been emptied;
+	// they are played out like the others if it is nil
+	Endgame *EndgameSolver
+	// Fallback picks the move outside of the pre-endgame;
+	// the Static strategy is used if nil
+	Fallback Strategy
+}
+
+// bagScenario is one possible draw: the opponent's rack, the tiles we
+// draw after our move and the tiles remaining in the bag
+type bagScenario struct {
+	opp    string
+	draw   string
+	bag    string
+	weight float64
+}
+
+// PickMove for PreEndgame selects the candidate with the best outcome
+// over the possible bag draws, when there are 1 to PreEndgameMaxBag
+// tiles in the bag, and defers to the Fallback strategy otherwise
+func (pe *PreEndgame) PickMove(state *GameState, moves []Move) Move {
+	if state.BagTileCount < 1 || state.BagTileCount > PreEndgameMaxBag {
+		fallback := pe.Fallback
+		if fallback == nil {
+			fallback = pe.static()
+		}
+		return fallback.PickMove(state, moves)
+	}
+	results := pe.Simulate(state, moves)
+	if len(results) == 0 {
+		return NewPassMove()
+	}
+	return results[0].Move
+}
+
+// Simulate evaluates the candidate moves over the possible bag draws
+// and returns their results from the best to the worst. The Iterations
+// of each result is the number of scenarios that were evaluated.
+func (pe *PreEndgame) Simulate(state *GameState, moves []Move) []*SimResult {
+	candidates := pe.candidates(state, moves)
+	var deadline time.Time
+	if pe.Budget > 0 {
+		deadline = time.Now().Add(pe.Budget)
+	}
+	limit := pe.Scenarios
+	if limit <= 0 {
+		limit = DefaultPreEndgameScenarios
+	}
+	unseen := state.UnseenLetters()
+
+	// Evaluate the candidates concurrently, one goroutine per candidate
+	resultsChan := make(chan *SimResult, len(candidates))
+	for _, candidate := range candidates {
+		go func(candidate EvaluatedMove) {
+			resultsChan <- pe.evaluate(state, candidate, unseen, limit, deadline)
+		}(candidate)
+	}
+	results := make([]*SimResult, 0, len(candidates))
+	for range candidates {
+		results = append(results, <-resultsChan)
+	}
+	sortSimResults(results, pe.ByWinRate)
+	return results
+}
+
+func (pe *PreEndgame) static() *StaticEval {
+	if pe.Static == nil {
+		return &StaticEval{}
+	}
+	return pe.Static
+}
+
+// candidates returns the best moves by equity, along with the best
+// bag-emptying moves and the best small exchanges
+func (pe *PreEndgame) candidates(state *GameState, moves []Move) []EvaluatedMove {
+	evaluated := pe.static().Evaluate(state, moves)
+	numCandidates := pe.Candidates
+	if numCandidates <= 0 {
+		numCandidates = DefaultSimCandidates
+	}
+	candidates := make([]EvaluatedMove, 0, numCandidates+2*preEndgameExtraCandidates)
+	seen := make(map[string]bool)
+	add := func(candidate EvaluatedMove) {
+		key := moveKey(candidate.Move)
+		if !seen[key] {
+			seen[key] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	for i := 0; i < len(evaluated) && i < numCandidates; i++ {
+		add(evaluated[i])
+	}
+	emptying, exchanges := 0, 0
+	for _, candidate := range evaluated {
+		switch m := candidate.Move.(type) {
+		case *TileMove:
+			if emptying < preEndgameExtraCandidates && len(m.Covers) >= state.BagTileCount {
+				add(candidate)
+				emptying++
+			}
+		case *ExchangeMove:
+			if exchanges < preEndgameExtraCandidates && len([]rune(m.Letters)) <= smallExchangeSize {
+				add(candidate)
+				exchanges++
+			}
+		}
+	}
+	return candidates
+}
+
+func (pe *PreEndgame) evaluate(state *GameState, candidate EvaluatedMove, unseen string, limit int, deadline time.Time) *SimResult {
+	result := &SimResult{Move: candidate.Move, Equity: candidate.Equity}
+	// Number of tiles we draw after the move
+	drawn := 0
+	switch m := candidate.Move.(type) {
+	case *TileMove:
+		drawn = minInt(len(m.Covers), state.BagTileCount)
+	case *ExchangeMove:
+		drawn = len([]rune(m.Letters))
+	}
+	var totalWeight, totalSpread, wins float64
+	for _, scenario := range bagScenarios(unseen, state.BagTileCount, drawn, limit) {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+		spread, ok := pe.playScenario(state, candidate.Move, scenario, deadline)
+		if !ok {
+			// Time ran out in the middle of the scenario
+			break
+		}
+		totalWeight += scenario.weight
+		totalSpread += scenario.weight * spread
+		switch final := float64(state.Spread) + spread; {
+		case final > 0:
+			wins += scenario.weight
+		case final == 0:
+			wins += scenario.weight / 2
+		}
+		result.Iterations++
+	}
+	if totalWeight > 0 {
+		result.Spread = totalSpread / totalWeight
+		result.WinRate = wins / totalWeight
+	}
+	return result
+}
+
+// playScenario plays the move with the given draw and returns the
+// resulting spread for us, or false if the endgame of the scenario
+// could not be solved before the deadline
+func (pe *PreEndgame) playScenario(state *GameState, move Move, scenario bagScenario, deadline time.Time) (float64, bool) {
+	board := state.cloneBoardState()
+	leave := RackLeave(state.Rack.AsString(), move)
+	bagLetters := scenario.bag
+	spread := float64(move.Score(state))
+	switch m := move.(type) {
+	case *TileMove:
+		if err := board.PlaceCovers(m.Covers, state.TileSet); err != nil {
+			// Should not happen with generated moves
+			return spread, true
+		}
+		if leave == "" && scenario.draw == "" {
+			// We went out: the game is over
+			return spread + float64(2*rackLettersValue(scenario.opp, state.TileSet)), true
+		}
+	case *ExchangeMove:
+		// The exchanged tiles go back to the bag after the draw
+		bagLetters += m.Letters
+	}
+	rack := newRackOf(leave+scenario.draw, state.TileSet)
+	opp := newRackOf(scenario.opp, state.TileSet)
+	bag := newBagOf(bagLetters, state.TileSet)
+
+	if bag.TileCount() == 0 && pe.Endgame != nil {
+		// Both racks are known: solve the endgame from
+		// the opponent's point of view
+		oppState := simulatedState(state, board, opp, bag)
+		result, err := pe.Endgame.solve(oppState, deadline)
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			// The search was cut short
+			return 0, false
+		}
+		if err == nil {
+			return spread - float64(result.Spread), true
+		}
+	}
+	return spread + simulateResponses(pe.static(), state, board, rack, opp, bag), true
+}
+
+// bagScenarios enumerates the ways the unseen tiles can be split between
+// the opponent's rack and a bag of bagSize tiles, and the tiles drawn
+// from that bag. Identical scenarios are merged and weighted by how many
+// times they occur. If there are more than limit scenarios, limit of them
+// are picked at random instead, with equal weights.
+func bagScenarios(unseen string, bagSize, drawn, limit int) []bagScenario {
+	letters := []rune(unseen)
+	if bagSize > len(letters) {
+		bagSize = len(letters)
+	}
+	if drawn > bagSize {
+		drawn = bagSize
+	}
+	if binomial(len(letters), bagSize)*binomial(bagSize, drawn) > limit {
+		scenarios := make([]bagScenario, 0, limit)
+		for len(scenarios) < limit {
+			// # nosec
+			rand.Shuffle(len(letters), func(i, j int) {
+				letters[i], letters[j] = letters[j], letters[i]
+			})
+			oppSize := len(letters) - bagSize
+			scenarios = append(scenarios, bagScenario{
+				opp:    SortLetters(string(letters[:oppSize])),
+				draw:   SortLetters(string(letters[oppSize : oppSize+drawn])),
+				bag:    string(letters[oppSize+drawn:]),
+				weight: 1,
+			})
+		}
+		return scenarios
+	}
+
+	index := make(map[string]int)
+	scenarios := make([]bagScenario, 0)
+	combinations(len(letters), bagSize, func(inBag []bool) {
+		var opp, bag []rune
+		for i, letter := range letters {
+			if inBag[i] {
+				bag = append(bag, letter)
+			} else {
+				opp = append(opp, letter)
+			}
+		}
+		combinations(len(bag), drawn, func(isDrawn []bool) {
+			var draw, rest []rune
+			for i, letter := range bag {
+				if isDrawn[i] {
+					draw = append(draw, letter)
+				} else {
+					rest = append(rest, letter)
+				}
+			}
+			scenario := bagScenario{
+				opp:    SortLetters(string(opp)),
+				draw:   SortLetters(string(draw)),
+				bag:    SortLetters(string(rest)),
+				weight: 1,
+			}
+			key := strings.Join([]string{scenario.opp, scenario.draw, scenario.bag}, "|")
+			if i, ok := index[key]; ok {
+				scenarios[i].weight++
+				return
+			}
+			index[key] = len(scenarios)
+			scenarios = append(scenarios, scenario)
+		})
+	})
+	return scenarios
+}
+
+// combinations calls fn for every way of choosing k items among n,
+// the chosen items being flagged in the slice passed to fn
+func combinations(n, k int, fn func(chosen []bool)) {
+	chosen := make([]bool, n)
+	var choose func(start, left int)
+	choose = func(start, left int) {
+		if left == 0 {
+			fn(chosen)
+			return
+		}
+		for i := start; i <= n-left; i++ {
+			chosen[i] = true
+			choose(i+1, left-1)
+			chosen[i] = false
+		}
+	}
+	choose(0, k)
+}
+
+// binomial returns the number of ways of choosing k items among n
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
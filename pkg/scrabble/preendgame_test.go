@@ -0,0 +1,98 @@
+package scrabble
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreEndgameBudget(t *testing.T) {
+	// One tile in the bag: every move but a pass empties
+	// it, leaving endgames that are long to solve
+	state := newEndgameState(t, "aestbce", "aeesttba")
+	state.BagTileCount = 1
+	pe := &PreEndgame{
+		Candidates: 3,
+		Budget:     100 * time.Millisecond,
+		Endgame:    &EndgameSolver{MaxDepth: 8},
+	}
+	start := time.Now()
+	move := pe.PickMove(state, state.GenerateMoves())
+	if elapsed := time.Since(start); elapsed > 10*pe.Budget {
+		t.Errorf("searching took %v with a budget of %v", elapsed, pe.Budget)
+	}
+	if _, ok := move.(*TileMove); !ok {
+		t.Errorf("got %v, want a tile move", move)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	tests := []struct{ n, k, want int }{
+		{0, 0, 1}, {5, 0, 1}, {5, 5, 1}, {5, 2, 10}, {8, 3, 56}, {3, 4, 0}, {3, -1, 0},
+	}
+	for _, tt := range tests {
+		if got := binomial(tt.n, tt.k); got != tt.want {
+			t.Errorf("binomial(%d, %d) = %d, want %d", tt.n, tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		for k := 0; k <= n; k++ {
+			seen := make(map[string]bool)
+			combinations(n, k, func(chosen []bool) {
+				key := ""
+				count := 0
+				for _, c := range chosen {
+					if c {
+						key += "1"
+						count++
+					} else {
+						key += "0"
+					}
+				}
+				if count != k {
+					t.Errorf("%d items chosen instead of %d among %d", count, k, n)
+				}
+				seen[key] = true
+			})
+			if len(seen) != binomial(n, k) {
+				t.Errorf("%d distinct ways of choosing %d among %d, want %d", len(seen), k, n, binomial(n, k))
+			}
+		}
+	}
+}
+
+func TestBagScenarios(t *testing.T) {
+	// Two of the unseen tiles are in the bag, one of which is drawn
+	unseen := "aabcd"
+	scenarios := bagScenarios(unseen, 2, 1, 1000)
+	total := 0.0
+	for _, s := range scenarios {
+		total += s.weight
+		if SortLetters(s.opp+s.draw+s.bag) != unseen {
+			t.Errorf("%+v does not split the unseen tiles", s)
+		}
+		if len(s.opp) != 3 || len(s.draw) != 1 || len(s.bag) != 1 {
+			t.Errorf("%+v has the wrong sizes", s)
+		}
+	}
+	if want := float64(binomial(5, 2) * binomial(2, 1)); total != want {
+		t.Errorf("total weight %v, want %v", total, want)
+	}
+	// The two a's make some scenarios identical: they are merged
+	if len(scenarios) >= binomial(5, 2)*binomial(2, 1) {
+		t.Errorf("%d scenarios were not merged", len(scenarios))
+	}
+
+	// Above the limit, scenarios are picked at random
+	scenarios = bagScenarios(unseen, 2, 1, 3)
+	if len(scenarios) != 3 {
+		t.Errorf("%d random scenarios, want 3", len(scenarios))
+	}
+	for _, s := range scenarios {
+		if s.weight != 1 || SortLetters(s.opp+s.draw+s.bag) != unseen {
+			t.Errorf("random scenario %+v is invalid", s)
+		}
+	}
+}
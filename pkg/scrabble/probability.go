@@ -0,0 +1,118 @@
+package scrabble
+
+import (
+	"sort"
+	"strings"
+)
+
+// RankedWord is a word with its probability of being drawn, see
+// TileSet.Probability, and its rank among the words it was ranked
+// with, from 1 for the most probable ones
+type RankedWord struct {
+	Word         string
+	Combinations float64
+	Probability  float64
+	Rank         int
+}
+
+// Combinations returns the number of ways to draw the tiles of a word
+// from a full bag, blanks standing for any letter. E.g. with 9 a's,
+// 2 b's and 2 blanks, "ab" can be drawn in 9*2 ways with natural tiles,
+// 9*2 + 2*2 ways with one blank and 1 way with both blanks.
+func (ts *TileSet) Combinations(word string) float64 {
+	needed := make(map[rune]int)
+	for _, letter := range strings.ToLower(word) {
+		needed[letter]++
+	}
+
+	// ways[b] is the number of ways to draw the letters
+	// seen so far using exactly b blanks
+	ways := []float64{1}
+	// The letters are taken in order, so that anagrams,
+	// rounding included, get the same result
+	letters := make([]rune, 0, len(needed))
+	for letter := range needed {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	for _, letter := range letters {
+		k := needed[letter]
+		next := make([]float64, len(ways)+k)
+		for b, w := range ways {
+			if w == 0 {
+				continue
+			}
+			// j of the k letters are drawn as blanks
+			for j := 0; j <= k; j++ {
+				next[b+j] += w * float64(binomial(ts.Count[letter], k-j))
+			}
+		}
+		ways = next
+	}
+
+	var result float64
+	for b, w := range ways {
+		result += w * float64(binomial(ts.Count['*'], b))
+	}
+	return result
+}
+
+// Probability returns the probability of drawing the tiles of a word,
+// blanks standing for any letter, when drawing as many tiles as the
+// word has letters from a full bag
+func (ts *TileSet) Probability(word string) float64 {
+	n := len([]rune(word))
+	total := 0
+	for _, count := range ts.Count {
+		total += count
+	}
+	if n > total {
+		return 0
+	}
+	// The number of ways to draw n tiles, as a float
+	// since it overflows an int for long words
+	draws := 1.0
+	for i := 1; i <= n; i++ {
+		draws = draws * float64(total-n+i) / float64(i)
+	}
+	return ts.Combinations(word) / draws
+}
+
+// RankWords returns the words from the most probable one, those with
+// the same probability, like anagrams, sharing the same rank and being
+// in alphabetical order
+func (ts *TileSet) RankWords(words []string) []RankedWord {
+	ranked := make([]RankedWord, len(words))
+	for i, word := range words {
+		ranked[i] = RankedWord{
+			Word:         word,
+			Combinations: ts.Combinations(word),
+			Probability:  ts.Probability(word),
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Probability != ranked[j].Probability {
+			return ranked[i].Probability > ranked[j].Probability
+		}
+		return ranked[i].Word < ranked[j].Word
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+		if i > 0 && ranked[i].Probability == ranked[i-1].Probability {
+			ranked[i].Rank = ranked[i-1].Rank
+		}
+	}
+	return ranked
+}
+
+// WordsByProbability returns the words of the given length of the DAWG,
+// ranked by their probability of being drawn from the TileSet, see
+// TileSet.RankWords
+func (d *DAWG) WordsByProbability(length int, ts *TileSet) []RankedWord {
+	if length <= 0 {
+		return nil
+	}
+	// The pattern is valid, so there is no error
+	words, _ := d.MatchPattern(strings.Repeat(".", length), 0, 0)
+	return ts.RankWords(words)
+}
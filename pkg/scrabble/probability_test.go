@@ -0,0 +1,52 @@
+package scrabble
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	ts := &TileSet{Count: map[rune]int{'a': 9, 'b': 2, '*': 2}}
+	for _, tt := range []struct {
+		word string
+		want float64
+	}{
+		// 18 ways with natural tiles, 22 with a blank, 1 with both
+		{"ab", 41},
+		{"BA", 41},
+		// bb*, twice, and b**, twice
+		{"bbb", 4},
+		// Only the blanks can be drawn for a c
+		{"c", 2},
+		{"cc", 1},
+		{"ccc", 0},
+	} {
+		if got := ts.Combinations(tt.word); got != tt.want {
+			t.Errorf("got %v combinations for %s, want %v", got, tt.word, tt.want)
+		}
+	}
+	// Among the 78 ways of drawing 2 of the 13 tiles
+	if got := ts.Probability("ab"); math.Abs(got-41.0/78) > 1e-12 {
+		t.Errorf("got probability %v for ab, want %v", got, 41.0/78)
+	}
+
+	var got []string
+	for _, r := range ts.RankWords([]string{"bb", "ba", "a", "ab"}) {
+		got = append(got, fmt.Sprintf("%d %s", r.Rank, r.Word))
+	}
+	if want := "1 a, 2 ab, 2 ba, 4 bb"; strings.Join(got, ", ") != want {
+		t.Errorf("got ranking %q, want %q", strings.Join(got, ", "), want)
+	}
+
+	ranked := newTestDAWG().WordsByProbability(4, DefaultTileSet)
+	if len(ranked) == 0 || ranked[0].Rank != 1 || len(ranked[0].Word) != 4 {
+		t.Fatalf("got ranking %+v", ranked)
+	}
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i].Probability > ranked[i-1].Probability {
+			t.Errorf("%s is ranked after the less probable %s", ranked[i].Word, ranked[i-1].Word)
+		}
+	}
+}
@@ -82,3 +82,12 @@ func (r *Rack) AsString() string {
 func (r *Rack) IsEmpty() bool {
 	return len(r.Tiles) == 0
 }
+
+// rackLettersValue returns the sum of the values of the given letters
+func rackLettersValue(letters string, tileSet *TileSet) int {
+	value := 0
+	for _, letter := range letters {
+		value += tileSet.Values[letter]
+	}
+	return value
+}
@@ -0,0 +1,257 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MoveGenDiff holds the differences between the moves found by
+// GenerateMoves and the ones found by GenerateReferenceMoves. Moves
+// laying the same letters on the same squares, whether with natural
+// or blank tiles, are considered the same: GenerateMoves finds only
+// one of them, while GenerateReferenceMoves finds them all.
+type MoveGenDiff struct {
+	// Missing moves are legal moves that GenerateMoves did not find
+	Missing []Move
+	// Extra moves were found by GenerateMoves but are not legal
+	Extra []Move
+	// Duplicates were found more than once by GenerateMoves
+	Duplicates []Move
+}
+
+// GenerateReferenceMoves returns every legal tile move, like
+// GenerateMoves, but the slow and simple way: it enumerates all the
+// placements of the rack tiles on the Board and keeps the ones that
+// TileMove.IsValid accepts with ValidateWords set. It is meant to check
+// the move generator, not to be used by bots. Unlike GenerateMoves, it
+// returns every way to lay the same letters with natural or blank tiles.
+func (gs *GameState) GenerateReferenceMoves() []Move {
+	game := &Game{Board: gs.Board, DAWG: gs.DAWG, TileSet: gs.TileSet}
+	rack := gs.Rack.AsString()
+	numTiles := len(gs.Rack.Tiles)
+	moves := make([]Move, 0)
+
+	for _, horizontal := range []bool{true, false} {
+		for line := 0; line < BoardSize; line++ {
+			for start := 0; start < BoardSize; start++ {
+				// The placements covering the empty square at start and
+				// the following empty squares of the line, if any
+				empty := make([]Position, 0, numTiles)
+				for i := start; i < BoardSize && len(empty) < numTiles; i++ {
+					pos := Position{Row: line, Col: i}
+					if !horizontal {
+						pos = Position{Row: i, Col: line}
+					}
+					if gs.Board.GetSquare(pos).Tile != nil {
+						if i == start {
+							break
+						}
+						continue
+					}
+					empty = append(empty, pos)
+				}
+				for n := 1; n <= len(empty); n++ {
+					if n == 1 && !horizontal {
+						// Single tile placements are the
+						// same in both directions
+						continue
+					}
+					r := referencePlacement{
+						game:      game,
+						positions: empty[:n],
+						covers:    make(Covers, n),
+					}
+					if !r.isPlacement() {
+						continue
+					}
+					r.fill(0, rack, func(move *TileMove) {
+						move.Score(gs)
+						moves = append(moves, move)
+					})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// DiffMoveGenerators compares the moves found by GenerateMoves with the
+// ones found by GenerateReferenceMoves in the given state
+func DiffMoveGenerators(state *GameState) *MoveGenDiff {
+	diff := &MoveGenDiff{}
+	// The reference moves by the letters they lay, and the ways
+	// to lay them with natural or blank tiles
+	reference := make(map[string]Move)
+	assignments := make(map[string]bool)
+	for _, move := range state.GenerateReferenceMoves() {
+		covers := move.(*TileMove).Covers
+		reference[lettersKey(covers)] = move
+		assignments[coversKey(covers)] = true
+	}
+	generated := make(map[string]Move)
+	for _, move := range state.GenerateMoves() {
+		tileMove, ok := move.(*TileMove)
+		if !ok {
+			continue
+		}
+		key := lettersKey(tileMove.Covers)
+		if _, ok := generated[key]; ok {
+			diff.Duplicates = append(diff.Duplicates, move)
+			continue
+		}
+		generated[key] = move
+		if !assignments[coversKey(tileMove.Covers)] {
+			diff.Extra = append(diff.Extra, move)
+		}
+	}
+	for key, move := range reference {
+		if _, ok := generated[key]; !ok {
+			diff.Missing = append(diff.Missing, move)
+		}
+	}
+	sort.Slice(diff.Missing, func(i, j int) bool {
+		return coversKey(diff.Missing[i].(*TileMove).Covers) < coversKey(diff.Missing[j].(*TileMove).Covers)
+	})
+	return diff
+}
+
+// OK returns true if both move generators agree
+func (d *MoveGenDiff) OK() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Duplicates) == 0
+}
+
+// String returns a string description of the MoveGenDiff
+func (d *MoveGenDiff) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d missing, %d extra, %d duplicate moves",
+		len(d.Missing), len(d.Extra), len(d.Duplicates)))
+	for _, group := range []struct {
+		name  string
+		moves []Move
+	}{{"missing", d.Missing}, {"extra", d.Extra}, {"duplicate", d.Duplicates}} {
+		for _, move := range group.moves {
+			sb.WriteString(fmt.Sprintf("\n  %s: %v %s", group.name, move, coversKey(move.(*TileMove).Covers)))
+		}
+	}
+	return sb.String()
+}
+
+// coversKey returns a string that identifies a set of covers
+func coversKey(covers Covers) string {
+	keys := make([]string, 0, len(covers))
+	for pos, cover := range covers {
+		keys = append(keys, fmt.Sprintf("%02d%02d%c%c", pos.Row, pos.Col, cover.Letter, cover.Actual))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " ")
+}
+
+// lettersKey returns a string that identifies the letters laid by a
+// set of covers, regardless of whether they are blank tiles
+func lettersKey(covers Covers) string {
+	letters := make(Covers, len(covers))
+	for pos, cover := range covers {
+		letters[pos] = Cover{Letter: cover.Actual, Actual: cover.Actual}
+	}
+	return coversKey(letters)
+}
+
+// referencePlacement enumerates the ways to lay tiles on given squares
+type referencePlacement struct {
+	game      *Game
+	positions []Position
+	covers    Covers
+}
+
+// isPlacement returns true if tiles can be laid on the squares
+// regardless of their letters, i.e. in a line, without gaps and
+// connected to the tiles already on the board
+func (r *referencePlacement) isPlacement() bool {
+	for _, pos := range r.positions {
+		r.covers[pos] = Cover{Letter: 'a', Actual: 'a'}
+	}
+	valid := NewUncheckedTileMove(r.game.Board, r.covers).IsValid(r.game)
+	for _, pos := range r.positions {
+		delete(r.covers, pos)
+	}
+	return valid
+}
+
+// fill tries every letter on the indexed square and the following ones,
+// given the remaining rack, and calls fn with the valid moves
+func (r *referencePlacement) fill(index int, rack string, fn func(*TileMove)) {
+	if index == len(r.positions) {
+		covers := make(Covers, len(r.covers))
+		for pos, cover := range r.covers {
+			covers[pos] = cover
+		}
+		move := NewTileMove(r.game.Board, covers)
+		if move.IsValid(r.game) {
+			fn(move)
+		}
+		return
+	}
+	pos := r.positions[index]
+	for letter := 'a'; letter <= 'z'; letter++ {
+		// The letter may be laid with a natural or a blank tile
+		for _, tile := range []rune{letter, '*'} {
+			if !strings.ContainsRune(rack, tile) {
+				continue
+			}
+			r.covers[pos] = Cover{Letter: tile, Actual: letter}
+			if r.promising(index) {
+				r.fill(index+1, strings.Replace(rack, string(tile), "", 1), fn)
+			}
+			delete(r.covers, pos)
+		}
+	}
+}
+
+// promising returns false if the letters laid so far, up to the indexed
+// square, cannot be part of a valid move, to prune the enumeration
+func (r *referencePlacement) promising(index int) bool {
+	b := r.game.Board
+	pos := r.positions[index]
+	horizontal := len(r.positions) == 1 || r.positions[0].Row == r.positions[1].Row
+	// The cross word through the square must be a word...
+	prev, after := b.CrossWordFragments(pos, !horizontal)
+	if (len(prev) > 0 || len(after) > 0) &&
+		!r.game.DAWG.IsWord(strings.ToLower(prev+string(r.covers[pos].Actual)+after)) {
+		return false
+	}
+	// ...and the main word up to the square must start a word
+	reverse := DirectionAbove
+	if horizontal {
+		reverse = DirectionLeft
+	}
+	word := b.WordFragment(r.positions[0], reverse)
+	for p := r.positions[0]; ; {
+		if cover, ok := r.covers[p]; ok {
+			word += string(cover.Actual)
+		} else {
+			word += string(b.GetSquare(p).Tile.Letter)
+		}
+		if p == pos {
+			break
+		}
+		if horizontal {
+			p.Col++
+		} else {
+			p.Row++
+		}
+	}
+	return hasPrefix(r.game.DAWG.Root, strings.ToLower(word))
+}
+
+// hasPrefix returns true if some word of the DAWG starts with the prefix
+func hasPrefix(n *Node, prefix string) bool {
+	for _, letter := range prefix {
+		next, ok := n.Edges[letter]
+		if !ok {
+			return false
+		}
+		n = next
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package scrabble
+
+import "testing"
+
+func TestGenerateReferenceMovesBlanks(t *testing.T) {
+	g := NewGame(DefaultTileSet, newTestDAWG())
+	state := &GameState{
+		DAWG:    g.DAWG,
+		TileSet: g.TileSet,
+		Board:   g.Board,
+		Rack:    newRackOf("at*", g.TileSet),
+	}
+	center := Position{Row: BoardCenter, Col: BoardCenter}
+	right := Position{Row: BoardCenter, Col: BoardCenter + 1}
+	// Every way to lay "at" across from the center square
+	want := map[string]bool{
+		coversKey(Covers{center: {Letter: 'a', Actual: 'a'}, right: {Letter: 't', Actual: 't'}}): true,
+		coversKey(Covers{center: {Letter: '*', Actual: 'a'}, right: {Letter: 't', Actual: 't'}}): true,
+		coversKey(Covers{center: {Letter: 'a', Actual: 'a'}, right: {Letter: '*', Actual: 't'}}): true,
+	}
+	found := 0
+	for _, move := range state.GenerateReferenceMoves() {
+		if want[coversKey(move.(*TileMove).Covers)] {
+			found++
+		}
+	}
+	if found != len(want) {
+		t.Errorf("found %d of the %d ways to lay at", found, len(want))
+	}
+
+	// The generator finds only one of them
+	generated := 0
+	for _, move := range state.GenerateMoves() {
+		if want[coversKey(move.(*TileMove).Covers)] {
+			generated++
+		}
+	}
+	if generated != 1 {
+		t.Errorf("generated %d ways to lay at, want 1", generated)
+	}
+	if diff := DiffMoveGenerators(state); !diff.OK() {
+		t.Errorf("%v", diff)
+	}
+}
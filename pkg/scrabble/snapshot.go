@@ -0,0 +1,146 @@
+package scrabble
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidSnapshot = errors.New("invalid board snapshot")
+
+// Snapshot is a position of a game, as needed to generate moves:
+// the board and the rack of the player to move
+type Snapshot struct {
+	Board *Board
+	Rack  string
+}
+
+// NewSnapshot returns a Snapshot of the game state, with a copy of its board
+func NewSnapshot(state *GameState) *Snapshot {
+	return &Snapshot{
+		Board: state.Board.Clone(),
+		Rack:  state.Rack.AsString(),
+	}
+}
+
+// State returns a GameState for the position of the Snapshot
+func (s *Snapshot) State(dawg *DAWG, tileSet *TileSet) *GameState {
+	return &GameState{
+		DAWG:       dawg,
+		TileSet:    tileSet,
+		Board:      s.Board,
+		Rack:       newRackOf(s.Rack, tileSet),
+		BoardState: NewBoardState(s.Board, dawg),
+	}
+}
+
+// LoadSnapshots reads the snapshots from the file at the
+// given path, see ReadSnapshots
+func LoadSnapshots(path string, tileSet *TileSet) ([]*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadSnapshots(f, tileSet)
+}
+
+// ReadSnapshots parses a list of snapshots. Each snapshot is made of
+// BoardSize lines of BoardSize squares, each one being '-' if empty or
+// else the letter of its tile, in uppercase for a blank tile, followed
+// by a "rack" line with the letters of the rack, '*' being a blank.
+// Empty lines and lines starting with '#' are ignored.
+func ReadSnapshots(r io.Reader, tileSet *TileSet) ([]*Snapshot, error) {
+	snapshots := make([]*Snapshot, 0)
+	var board *Board
+	row := 0
+
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanLines)
+
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if row < BoardSize {
+			squares := []rune(line)
+			if len(squares) != BoardSize {
+				return nil, fmt.Errorf("%w: line %d: expected %d squares", ErrInvalidSnapshot, lineNum, BoardSize)
+			}
+			if board == nil {
+				board = NewBoard()
+			}
+			for col, letter := range squares {
+				if letter == '-' {
+					continue
+				}
+				if !unicode.IsLetter(letter) {
+					return nil, fmt.Errorf("%w: line %d: invalid square %q", ErrInvalidSnapshot, lineNum, letter)
+				}
+				tile := &Tile{Letter: letter, Value: tileSet.Values[letter]}
+				if tile.IsBlank() {
+					tile.Value = 0
+				}
+				board.Squares[row][col].Tile = tile
+			}
+			row++
+			continue
+		}
+		fields := strings.Fields(line)
+		if fields[0] != "rack" || len(fields) > 2 {
+			return nil, fmt.Errorf("%w: line %d: expected the rack", ErrInvalidSnapshot, lineNum)
+		}
+		snapshot := &Snapshot{Board: board}
+		if len(fields) == 2 {
+			snapshot.Rack = fields[1]
+		}
+		snapshots = append(snapshots, snapshot)
+		board, row = nil, 0
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if board != nil {
+		return nil, fmt.Errorf("%w: incomplete snapshot at the end", ErrInvalidSnapshot)
+	}
+
+	return snapshots, nil
+}
+
+// SaveSnapshots writes the snapshots to the file at the given
+// path, see WriteSnapshots
+func SaveSnapshots(path string, snapshots []*Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteSnapshots(f, snapshots); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteSnapshots writes the snapshots in the format read by ReadSnapshots
+func WriteSnapshots(w io.Writer, snapshots []*Snapshot) error {
+	bw := bufio.NewWriter(w)
+	for i, snapshot := range snapshots {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		for row := 0; row < BoardSize; row++ {
+			for col := 0; col < BoardSize; col++ {
+				bw.WriteString(snapshot.Board.Squares[row][col].String())
+			}
+			bw.WriteString("\n")
+		}
+		fmt.Fprintf(bw, "rack %s\n", snapshot.Rack)
+	}
+	return bw.Flush()
+}
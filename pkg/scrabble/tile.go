@@ -1,6 +1,14 @@
 package scrabble
 
+import "unicode"
+
 type Tile struct {
 	Letter rune
 	Value  int
 }
+
+// IsBlank returns true if the tile is a blank, either still
+// unplayed ('*') or laid on the board with an uppercase designation
+func (t *Tile) IsBlank() bool {
+	return t.Letter == '*' || unicode.IsUpper(t.Letter)
+}
@@ -0,0 +1,169 @@
+package scrabble
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+)
+
+// TopKStrategy is implemented by strategies that only ever pick a move
+// among the K highest scoring tile moves. Bots using them generate those
+// moves only, instead of every legal move.
+type TopKStrategy interface {
+	Strategy
+	TopK() int
+}
+
+// Make sure the strategies implement the TopKStrategy interface
+var (
+	_ TopKStrategy = (*HighScore)(nil)
+	_ TopKStrategy = (*OneOfNBest)(nil)
+)
+
+// moveHeap is a min-heap of tile moves by score, which keeps
+// the best moves found so far
+type moveHeap []*TileMove
+
+func (h moveHeap) Len() int {
+	return len(h)
+}
+
+func (h moveHeap) Less(i, j int) bool {
+	return *h[i].CachedScore < *h[j].CachedScore
+}
+
+func (h moveHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *moveHeap) Push(x any) {
+	*h = append(*h, x.(*TileMove))
+}
+
+func (h *moveHeap) Pop() any {
+	old := *h
+	n := len(old)
+	move := old[n-1]
+	*h = old[:n-1]
+	return move
+}
+
+// wants returns true if a move with the given score would
+// make it into a heap of the k best moves
+func (h moveHeap) wants(score int, k int) bool {
+	return len(h) < k || score > *h[0].CachedScore
+}
+
+// add adds the move to a heap of the k best moves,
+// dropping the lowest scoring move if needed
+func (h *moveHeap) add(move *TileMove, k int) {
+	if len(*h) < k {
+		heap.Push(h, move)
+		return
+	}
+	(*h)[0] = move
+	heap.Fix(h, 0)
+}
+
+// GenerateTopMoves returns the k highest scoring tile moves, from the
+// highest to the lowest score. Moves are scored as they are found and
+// only the ones that make it into the top k are built, which saves most
+// of the allocations of GenerateMoves.
+func (gs *GameState) GenerateTopMoves(k int) []Move {
+	if k <= 0 {
+		return nil
+	}
+	leftParts := gs.DAWG.FindLeftParts(gs.Rack.AsString())
+
+	resultsChan := make(chan moveHeap, BoardSize*2)
+	// Start the 30 goroutines (columns and rows = 2 * BoardSize),
+	// each one keeping its own top k moves
+	for index := 0; index < BoardSize; index++ {
+		for _, horizontal := range []bool{true, false} {
+			go func(index int, horizontal bool) {
+				var axis Axis
+				axis.Init(gs, index, horizontal)
+				axis.topK = k
+				axis.GenerateMoves(leftParts)
+				resultsChan <- axis.best
+			}(index, horizontal)
+		}
+	}
+
+	// Merge the top k moves of every axis
+	best := make(moveHeap, 0, k)
+	for i := 0; i < BoardSize*2; i++ {
+		for _, move := range <-resultsChan {
+			if best.wants(*move.CachedScore, k) {
+				best.add(move, k)
+			}
+		}
+	}
+	sort.Slice(best, func(i, j int) bool {
+		return *best[i].CachedScore > *best[j].CachedScore
+	})
+	moves := make([]Move, len(best))
+	for i, move := range best {
+		moves[i] = move
+	}
+	return moves
+}
+
+// scoreMatch returns the score of the word made of the given runes,
+// laid on the axis from the start index, without building a TileMove.
+// It gives the same result as TileMove.Score.
+func (a *Axis) scoreMatch(start int, runes []rune) int {
+	values := a.state.TileSet.Values
+	rack := a.rackString
+	score, crossScore, multiplier, covered := 0, 0, 1, 0
+	for i, actual := range runes {
+		index := start + i
+		sq := a.squares[index]
+		if sq.Tile != nil {
+			// This square was already covered: add its letter score only
+			score += sq.Tile.Value
+			continue
+		}
+		letter := actual
+		if strings.ContainsRune(rack, actual) {
+			rack = strings.Replace(rack, string(actual), "", 1)
+		} else {
+			// Must be using a blank tile
+			letter = '*'
+			rack = strings.Replace(rack, "*", "", 1)
+		}
+		covered++
+		sc := values[letter] * sq.LetterMultiplier
+		score += sc
+		multiplier *= sq.WordMultiplier
+		if hasCrossing, csc := a.crossScore(index); hasCrossing {
+			crossScore += (csc + sc) * sq.WordMultiplier
+		}
+	}
+	score = score*multiplier + crossScore
+	if covered == RackSize {
+		score += BingoBonus
+	}
+	return score
+}
+
+// crossScore returns the sum of the scores of the tiles crossing the
+// indexed square, as Board.CrossScore does, computing it only once
+func (a *Axis) crossScore(index int) (bool, int) {
+	if !a.crossScoreKnown[index] {
+		a.hasCrossing[index], a.crossScores[index] =
+			a.state.Board.CrossScore(a.squares[index].Position, !a.horizontal)
+		a.crossScoreKnown[index] = true
+	}
+	return a.hasCrossing[index], a.crossScores[index]
+}
+
+// TopK returns 1, as HighScore only needs the highest scoring move
+func (hs *HighScore) TopK() int {
+	return 1
+}
+
+// TopK returns N, as OneOfNBest picks among the N highest scoring moves
+func (ofb *OneOfNBest) TopK() int {
+	return ofb.N
+}
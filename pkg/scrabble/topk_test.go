@@ -0,0 +1,46 @@
+package scrabble
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTopMoves(t *testing.T) {
+	for _, rack := range []string{"aestb", "*aest", "**", "etabsac"} {
+		t.Run(strings.ReplaceAll(rack, "*", "blank"), func(t *testing.T) {
+			g := newTestGame(t)
+			state := g.State()
+			state.Rack = newRackOf(rack, g.TileSet)
+
+			all := state.GenerateMoves()
+			scores := make([]int, len(all))
+			for i, move := range all {
+				scores[i] = move.Score(state)
+			}
+			sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+
+			for _, k := range []int{1, 5, 20, len(all) + 10} {
+				top := state.GenerateTopMoves(k)
+				got := make([]int, len(top))
+				for i, move := range top {
+					got[i] = move.Score(state)
+					// The score of the move, blanks included, is
+					// the one it gets when scored from scratch
+					covers := move.(*TileMove).Covers
+					if score := NewTileMove(state.Board, covers).Score(state); score != got[i] {
+						t.Errorf("%v scores %d from scratch", move, score)
+					}
+				}
+				want := scores
+				if k < len(want) {
+					want = want[:k]
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("top %d scores %v, want %v", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package scrabble
+
+// UnseenTiles returns, for each letter, the number of tiles that the
+// player to move cannot see. From the player's perspective, the unseen
+// pool is the bag plus the opponent's rack: it is computed as the whole
+// TileSet minus the tiles on the board minus the tiles on the player's
+// own Rack. Blanks laid on the board are counted as '*'.
+func (gs *GameState) UnseenTiles() map[rune]int {
+	return unseenTiles(gs.TileSet, gs.Board, gs.Rack)
+}
+
+// UnseenCount returns the total number of unseen tiles
+func (gs *GameState) UnseenCount() int {
+	count := 0
+	for _, n := range gs.UnseenTiles() {
+		count += n
+	}
+	return count
+}
+
+// UnseenLetters returns the unseen tiles as a sorted string of letters,
+// with one rune per tile, e.g. "**aabeeu"
+func (gs *GameState) UnseenLetters() string {
+	return lettersOf(gs.UnseenTiles())
+}
+
+// OpponentRackSize infers the number of tiles on the opponent's rack,
+// which is every unseen tile that is not in the bag
+func (gs *GameState) OpponentRackSize() int {
+	return gs.UnseenCount() - gs.BagTileCount
+}
+
+// UnseenTiles returns the unseen tile counts from the perspective
+// of the given player of the Game, see GameState.UnseenTiles
+func (g *Game) UnseenTiles(p *Player) map[rune]int {
+	return unseenTiles(g.TileSet, g.Board, p.Rack)
+}
+
+func unseenTiles(ts *TileSet, b *Board, r *Rack) map[rune]int {
+	unseen := make(map[rune]int, len(ts.Count))
+	for letter, count := range ts.Count {
+		unseen[letter] = count
+	}
+	// Remove the tiles that have been laid on the board
+	for row := 0; row < BoardSize; row++ {
+		for col := 0; col < BoardSize; col++ {
+			tile := b.Squares[row][col].Tile
+			if tile == nil {
+				continue
+			}
+			if tile.IsBlank() {
+				unseen['*']--
+			} else {
+				unseen[tile.Letter]--
+			}
+		}
+	}
+	// Remove the tiles on the player's own rack
+	if r != nil {
+		for _, tile := range r.Tiles {
+			unseen[tile.Letter]--
+		}
+	}
+	for letter, count := range unseen {
+		if count <= 0 {
+			delete(unseen, letter)
+		}
+	}
+	return unseen
+}
+
+// lettersOf converts a map of letter counts into a sorted string
+// containing each letter as many times as it is counted
+func lettersOf(counts map[rune]int) string {
+	letters := make([]rune, 0, TotalTiles)
+	for letter, count := range counts {
+		for i := 0; i < count; i++ {
+			letters = append(letters, letter)
+		}
+	}
+	return SortLetters(string(letters))
+}
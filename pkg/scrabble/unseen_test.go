@@ -0,0 +1,55 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnseenTiles(t *testing.T) {
+	// The board holds c, a, t, s, a blank and b
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("abe*", g.TileSet)
+	state.BagTileCount = TotalTiles - 6 - 4 - RackSize
+
+	unseen := state.UnseenTiles()
+	for letter, want := range map[rune]int{
+		'a': g.TileSet.Count['a'] - 2,
+		'b': g.TileSet.Count['b'] - 2,
+		'c': g.TileSet.Count['c'] - 1,
+		'e': g.TileSet.Count['e'] - 1,
+		'*': g.TileSet.Count['*'] - 2,
+		'z': g.TileSet.Count['z'],
+	} {
+		if got := unseen[letter]; got != want {
+			t.Errorf("%d unseen %c, want %d", got, letter, want)
+		}
+	}
+	if got, want := state.UnseenCount(), TotalTiles-10; got != want {
+		t.Errorf("%d unseen tiles, want %d", got, want)
+	}
+	if got := state.OpponentRackSize(); got != RackSize {
+		t.Errorf("the opponent has %d tiles, want %d", got, RackSize)
+	}
+	letters := state.UnseenLetters()
+	if len(letters) != TotalTiles-10 || letters != SortLetters(letters) {
+		t.Errorf("UnseenLetters() = %q is not sorted or has the wrong length", letters)
+	}
+	if strings.Count(letters, "*") != unseen['*'] {
+		t.Errorf("UnseenLetters() = %q does not match the unseen blanks", letters)
+	}
+}
+
+func TestUnseenTilesOfPlayer(t *testing.T) {
+	g := newTestGame(t)
+	for _, p := range g.Players {
+		// Each player sees the 6 tiles on the board and its own rack
+		count := 0
+		for _, n := range g.UnseenTiles(p) {
+			count += n
+		}
+		if want := TotalTiles - 6 - len(p.Rack.Tiles); count != want {
+			t.Errorf("%s: %d unseen tiles, want %d", p.Username, count, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package scrabble
+
+// Make sure the vocabulary wrapper implements the Strategy interface
+var _ Strategy = (*Vocabulary)(nil)
+
+// Vocabulary wraps a Strategy so that it only picks tile moves whose
+// words, the main word and the cross words alike, all belong to a
+// secondary "known vocabulary" DAWG, e.g. a list of words suitable for
+// kids. Exchanges and passes are left to the wrapped Strategy.
+type Vocabulary struct {
+	Strategy
+	Known *DAWG
+}
+
+func NewVocabulary(s Strategy, known *DAWG) *Vocabulary {
+	return &Vocabulary{
+		Strategy: s,
+		Known:    known,
+	}
+}
+
+// PickMove for Vocabulary lets the wrapped Strategy pick a move among
+// the tile moves that only use known words
+func (v *Vocabulary) PickMove(state *GameState, moves []Move) Move {
+	return v.Strategy.PickMove(state, v.Filter(state, moves))
+}
+
+// Filter returns the moves that only form words of the known vocabulary
+func (v *Vocabulary) Filter(state *GameState, moves []Move) []Move {
+	known := make([]Move, 0, len(moves))
+	for _, move := range moves {
+		if v.IsKnown(state.Board, move) {
+			known = append(known, move)
+		}
+	}
+	return known
+}
+
+// IsKnown returns true if every word formed by the move on the
+// board belongs to the known vocabulary
+func (v *Vocabulary) IsKnown(b *Board, move Move) bool {
+	tileMove, ok := move.(*TileMove)
+	if !ok {
+		return true
+	}
+	for _, word := range tileMove.Words(b) {
+		if !v.Known.IsWord(word) {
+			return false
+		}
+	}
+	return true
+}
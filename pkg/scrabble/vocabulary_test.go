@@ -0,0 +1,36 @@
+package scrabble
+
+import "testing"
+
+func TestVocabulary(t *testing.T) {
+	g := newTestGame(t)
+	state := g.State()
+	state.Rack = newRackOf("aestb", g.TileSet)
+	moves := append(state.GenerateMoves(), NewExchangeMove("b"))
+
+	known := []string{"cats", "tab", "tabs", "at", "ta", "bat", "bats"}
+	v := NewVocabulary(&HighScore{}, NewDawg(&Dictionary{Words: known}))
+	filtered := v.Filter(state, moves)
+	if len(filtered) == 0 || len(filtered) >= len(moves) {
+		t.Fatalf("%d of %d moves are known", len(filtered), len(moves))
+	}
+	exchanges := 0
+	for _, move := range filtered {
+		tileMove, ok := move.(*TileMove)
+		if !ok {
+			exchanges++
+			continue
+		}
+		for _, word := range tileMove.Words(state.Board) {
+			if !v.Known.IsWord(word) {
+				t.Errorf("%v forms the unknown word %q", move, word)
+			}
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("%d exchanges kept, want 1", exchanges)
+	}
+	if move := v.PickMove(state, moves); !v.IsKnown(state.Board, move) {
+		t.Errorf("picked %v, which is not known", move)
+	}
+}
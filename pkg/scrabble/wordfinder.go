@@ -0,0 +1,338 @@
+package scrabble
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidPattern is returned for a pattern that cannot be parsed
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// maxPatternTokens is the maximum number of letters and letter classes
+// of a pattern, its states being kept in the bits of a uint64
+const maxPatternTokens = 63
+
+// AnagramNavigator finds the words that can be formed with the tiles
+// of a rack, '*' being a blank that can stand for any letter
+type AnagramNavigator struct {
+	rack    string
+	minLen  int
+	maxLen  int
+	index   int
+	stack   []leftPermItem
+	results []string
+}
+
+// PatternNavigator finds the words matching a compiled pattern,
+// see DAWG.MatchPattern. It runs the pattern as a nondeterministic
+// automaton, its current states being the bits of a uint64.
+type PatternNavigator struct {
+	tokens  []patternToken
+	states  uint64
+	minLen  int
+	maxLen  int
+	index   int
+	stack   []patternItem
+	results []string
+}
+
+// patternToken is a letter or a letter class of a pattern
+type patternToken struct {
+	letters  LetterSet
+	optional bool
+	repeated bool
+}
+
+type patternItem struct {
+	states uint64
+	index  int
+}
+
+// Init initializes an AnagramNavigator with the rack, to find the words
+// of at least minLen letters. The longest words use the whole rack.
+func (an *AnagramNavigator) Init(rack string, minLen int) {
+	an.rack = rack
+	an.minLen = minLen
+	an.maxLen = len([]rune(rack))
+	an.stack = make([]leftPermItem, 0, an.maxLen)
+	an.results = make([]string, 0)
+}
+
+// PushEdge determines whether the navigation should proceed into
+// an edge having c as its first letter
+func (an *AnagramNavigator) PushEdge(c rune) bool {
+	if !strings.ContainsRune(an.rack, c) && !strings.ContainsRune(an.rack, '*') {
+		return false
+	}
+	an.stack = append(an.stack, leftPermItem{an.rack, an.index})
+	return true
+}
+
+// PopEdge returns false if there is no need to visit other edges
+// after this one has been traversed
+func (an *AnagramNavigator) PopEdge() bool {
+	// Pop the previous rack and index from the stack
+	last := len(an.stack) - 1
+	an.rack, an.index = an.stack[last].rack, an.stack[last].index
+	an.stack = an.stack[0:last]
+	return true
+}
+
+// IsAccepting returns false if the navigator should not expect more
+// characters
+func (an *AnagramNavigator) IsAccepting() bool {
+	return an.index < an.maxLen
+}
+
+// Accepts returns true if the navigator should accept and 'eat' the
+// given character
+func (an *AnagramNavigator) Accepts(c rune) bool {
+	// Natural tiles are used before blanks, so that every word
+	// is only found once
+	if strings.ContainsRune(an.rack, c) {
+		an.rack = strings.Replace(an.rack, string(c), "", 1)
+	} else if strings.ContainsRune(an.rack, '*') {
+		an.rack = strings.Replace(an.rack, "*", "", 1)
+	} else {
+		return false
+	}
+	an.index++
+	return true
+}
+
+// Accept is called to inform the navigator of a match and
+// whether it is a isWord word
+func (an *AnagramNavigator) Accept(matched string, isWord bool, ns *navState) {
+	if isWord && an.index >= an.minLen {
+		an.results = append(an.results, matched)
+	}
+}
+
+// Init initializes a PatternNavigator with a pattern, see compilePattern,
+// to find the words of minLen to maxLen letters, maxLen being unbounded
+// if zero
+func (pn *PatternNavigator) Init(pattern string, minLen, maxLen int) error {
+	tokens, err := compilePattern(pattern)
+	if err != nil {
+		return err
+	}
+	pn.tokens = tokens
+	pn.states = pn.closure(1)
+	pn.minLen = minLen
+	pn.maxLen = maxLen
+	pn.stack = make([]patternItem, 0)
+	pn.results = make([]string, 0)
+	return nil
+}
+
+// closure adds to the states those reached by skipping optional tokens
+func (pn *PatternNavigator) closure(states uint64) uint64 {
+	for i, token := range pn.tokens {
+		if token.optional && states&(1<<i) != 0 {
+			states |= 1 << (i + 1)
+		}
+	}
+	return states
+}
+
+// step returns the states reached from the current ones with the
+// letter c, none if the pattern cannot match it
+func (pn *PatternNavigator) step(c rune) uint64 {
+	var next uint64
+	for i, token := range pn.tokens {
+		if pn.states&(1<<i) != 0 && token.letters.Contains(c) {
+			next |= 1 << (i + 1)
+			if token.repeated {
+				next |= 1 << i
+			}
+		}
+	}
+	return pn.closure(next)
+}
+
+// PushEdge determines whether the navigation should proceed into
+// an edge having c as its first letter
+func (pn *PatternNavigator) PushEdge(c rune) bool {
+	if pn.step(c) == 0 {
+		return false
+	}
+	pn.stack = append(pn.stack, patternItem{pn.states, pn.index})
+	return true
+}
+
+// PopEdge returns false if there is no need to visit other edges
+// after this one has been traversed
+func (pn *PatternNavigator) PopEdge() bool {
+	last := len(pn.stack) - 1
+	pn.states, pn.index = pn.stack[last].states, pn.stack[last].index
+	pn.stack = pn.stack[0:last]
+	return true
+}
+
+// IsAccepting returns false if the navigator should not expect more
+// characters
+func (pn *PatternNavigator) IsAccepting() bool {
+	return pn.states != 0 && (pn.maxLen == 0 || pn.index < pn.maxLen)
+}
+
+// Accepts returns true if the navigator should accept and 'eat' the
+// given character
+func (pn *PatternNavigator) Accepts(c rune) bool {
+	next := pn.step(c)
+	if next == 0 {
+		return false
+	}
+	pn.states = next
+	pn.index++
+	return true
+}
+
+// Accept is called to inform the navigator of a match and
+// whether it is a isWord word
+func (pn *PatternNavigator) Accept(matched string, isWord bool, ns *navState) {
+	final := uint64(1) << len(pn.tokens)
+	if isWord && pn.states&final != 0 && pn.index >= pn.minLen {
+		pn.results = append(pn.results, matched)
+	}
+}
+
+// compilePattern parses a pattern into its tokens. A pattern is made
+// of letters, '.' for any letter, and letter classes such as [aeiou],
+// [a-m] or [^aeiou]. Each of them can be followed by '?' when optional,
+// '*' when repeated zero or more times, or '+' when repeated at least
+// once.
+func compilePattern(pattern string) ([]patternToken, error) {
+	runes := []rune(strings.ToLower(pattern))
+	tokens := make([]patternToken, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		var letters LetterSet
+		switch c := runes[i]; {
+		case c == '.':
+			letters = AllLetters
+		case c == '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, ErrInvalidPattern
+			}
+			var err error
+			if letters, err = parseLetterClass(runes[i+1 : end]); err != nil {
+				return nil, err
+			}
+			i = end
+		case c >= 'a' && c <= 'z':
+			letters = letters.Add(c)
+		default:
+			return nil, ErrInvalidPattern
+		}
+
+		token := patternToken{letters: letters}
+		if i+1 < len(runes) {
+			switch runes[i+1] {
+			case '?':
+				token.optional = true
+				i++
+			case '*':
+				token.optional, token.repeated = true, true
+				i++
+			case '+':
+				// Once, then zero or more times
+				tokens = append(tokens, token)
+				token.optional, token.repeated = true, true
+				i++
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) > maxPatternTokens {
+		return nil, ErrInvalidPattern
+	}
+	return tokens, nil
+}
+
+// parseLetterClass returns the letters of a class, without its brackets
+func parseLetterClass(class []rune) (LetterSet, error) {
+	negated := len(class) > 0 && class[0] == '^'
+	if negated {
+		class = class[1:]
+	}
+	if len(class) == 0 {
+		return 0, ErrInvalidPattern
+	}
+	var letters LetterSet
+	for i := 0; i < len(class); i++ {
+		from, to := class[i], class[i]
+		if i+2 < len(class) && class[i+1] == '-' {
+			to = class[i+2]
+			i += 2
+		}
+		if from < 'a' || to > 'z' || from > to {
+			return 0, ErrInvalidPattern
+		}
+		for c := from; c <= to; c++ {
+			letters = letters.Add(c)
+		}
+	}
+	if negated {
+		letters = AllLetters &^ letters
+	}
+	return letters, nil
+}
+
+// Anagrams returns the words that use all the tiles of the rack,
+// '*' being a blank, in alphabetical order
+func (d *DAWG) Anagrams(rack string) []string {
+	rack = strings.ToLower(rack)
+	return d.anagrams(rack, len([]rune(rack)))
+}
+
+// SubAnagrams returns the words of at least minLen letters that can be
+// formed with tiles of the rack, '*' being a blank. The longest words
+// come first, then the words of the same length in alphabetical order.
+func (d *DAWG) SubAnagrams(rack string, minLen int) []string {
+	words := d.anagrams(strings.ToLower(rack), maxInt(minLen, 1))
+	sort.SliceStable(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+	return words
+}
+
+func (d *DAWG) anagrams(rack string, minLen int) []string {
+	var an AnagramNavigator
+	an.Init(rack, minLen)
+	d.Navigate(&an)
+	sort.Strings(an.results)
+	return an.results
+}
+
+// MatchPattern returns the words of minLen to maxLen letters matching
+// the whole pattern, in alphabetical order. maxLen is unbounded if
+// zero. See compilePattern for the syntax of the pattern, e.g. "qu.*"
+// for the words starting with qu, or "[^s]+ing" for the words ending
+// with ing but not sing.
+func (d *DAWG) MatchPattern(pattern string, minLen, maxLen int) ([]string, error) {
+	var pn PatternNavigator
+	if err := pn.Init(pattern, minLen, maxLen); err != nil {
+		return nil, err
+	}
+	d.Navigate(&pn)
+	sort.Strings(pn.results)
+	return pn.results, nil
+}
+
+// Containing returns the words containing a match of the pattern,
+// in alphabetical order, e.g. "q[^u]" for the words having a q
+// which is not followed by a u
+func (d *DAWG) Containing(pattern string) ([]string, error) {
+	return d.MatchPattern(".*"+pattern+".*", 0, 0)
+}
+
+// Hooks returns the letters that make another word when put in front
+// of the word, and those that do so when put after it
+func (d *DAWG) Hooks(word string) (front, back []rune) {
+	word = strings.ToLower(word)
+	return d.CrossCheck("", word), d.CrossCheck(word, "")
+}
@@ -0,0 +1,55 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWordFinder(t *testing.T) {
+	d := newTestDAWG()
+	for _, tt := range []struct {
+		name string
+		got  []string
+		want string
+	}{
+		{"anagrams", d.Anagrams("east"), "east eats seat teas"},
+		{"anagrams with a blank", d.Anagrams("AT*"), "act ate bat cat eat sat sta tab tea"},
+		{"sub-anagrams", d.SubAnagrams("tabs", 2), "bats stab tabs bat sat sta tab ab at ta"},
+	} {
+		if got := strings.Join(tt.got, " "); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		pattern        string
+		minLen, maxLen int
+		want           string
+		err            error
+	}{
+		{"b.*", 0, 0, "bat bats be beast beat beats best bet bets", nil},
+		{"b.*", 3, 3, "bat bet", nil},
+		{"[^b]at", 0, 0, "cat eat sat", nil},
+		{"[a-c]+.", 0, 0, "ab act at bat be cab cabs cat", nil},
+		{"be?ats?", 0, 0, "bat bats beat beats", nil},
+		{"[ab", 0, 0, "", ErrInvalidPattern},
+		{"[z-a]", 0, 0, "", ErrInvalidPattern},
+		{"*t", 0, 0, "", ErrInvalidPattern},
+		{"a#", 0, 0, "", ErrInvalidPattern},
+	} {
+		words, err := d.MatchPattern(tt.pattern, tt.minLen, tt.maxLen)
+		if err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.pattern, err, tt.err)
+		}
+		if got := strings.Join(words, " "); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+
+	if words, _ := d.Containing("ab"); strings.Join(words, " ") != "ab abet cab cabs scab stab tab tabs" {
+		t.Errorf("got %v containing ab", words)
+	}
+	if front, back := d.Hooks("eat"); string(front) != "bs" || string(back) != "s" {
+		t.Errorf("got hooks %q and %q for eat, want bs and s", string(front), string(back))
+	}
+}
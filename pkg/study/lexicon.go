@@ -0,0 +1,154 @@
+package study
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"scrabble/pkg/scrabble"
+)
+
+// Question asks for the words that can be made with all the letters
+// of an alphagram
+type Question struct {
+	// Alphagram is the letters of the words in alphabetical order
+	Alphagram string `json:"alphagram"`
+	// Answers are the words of the lexicon having these letters
+	Answers []string `json:"answers"`
+	// Combinations is the number of ways to draw the letters of the
+	// alphagram from a full bag, and Probability the probability of
+	// drawing them, see TileSet.Probability
+	Combinations float64 `json:"combinations"`
+	Probability  float64 `json:"probability"`
+	// Rank is the rank of the words of the alphagram among the words
+	// of the same length, from the most probable one, starting at 1,
+	// as given by DAWG.WordsByProbability
+	Rank int `json:"rank"`
+}
+
+// Answer is the outcome of a Question, the guesses being
+// checked against the DAWG of the lexicon
+type Answer struct {
+	Alphagram string   `json:"alphagram"`
+	Correct   []string `json:"correct"`
+	Missed    []string `json:"missed"`
+	Wrong     []string `json:"wrong"`
+}
+
+// Lexicon generates the alphagram questions of a DAWG, drawing the
+// letters from a TileSet, blanks included. The questions of each word
+// length are computed once, when first needed. A Lexicon is safe for
+// concurrent use.
+type Lexicon struct {
+	DAWG    *scrabble.DAWG
+	TileSet *scrabble.TileSet
+
+	mu          sync.Mutex
+	byLength    map[int][]*Question
+	byAlphagram map[string]*Question
+}
+
+// NewLexicon returns a Lexicon of the words of a DAWG
+func NewLexicon(dawg *scrabble.DAWG, tileSet *scrabble.TileSet) *Lexicon {
+	return &Lexicon{
+		DAWG:        dawg,
+		TileSet:     tileSet,
+		byLength:    make(map[int][]*Question),
+		byAlphagram: make(map[string]*Question),
+	}
+}
+
+// Alphagram returns the letters of a word in alphabetical order
+func Alphagram(word string) string {
+	letters := []rune(strings.ToLower(word))
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
+
+// Questions returns the questions of the words of the given length,
+// from the most probable alphagram, those with the same probability
+// being in alphabetical order
+func (l *Lexicon) Questions(length int) []*Question {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.questions(length)
+}
+
+func (l *Lexicon) questions(length int) []*Question {
+	if questions, ok := l.byLength[length]; ok {
+		return questions
+	}
+
+	var questions []*Question
+	byAlphagram := make(map[string]*Question)
+	// Anagrams being as probable, they share a rank
+	for _, ranked := range l.DAWG.WordsByProbability(length, l.TileSet) {
+		alphagram := Alphagram(ranked.Word)
+		q, ok := byAlphagram[alphagram]
+		if !ok {
+			q = &Question{
+				Alphagram:    alphagram,
+				Combinations: ranked.Combinations,
+				Probability:  ranked.Probability,
+				Rank:         ranked.Rank,
+			}
+			byAlphagram[alphagram] = q
+			questions = append(questions, q)
+		}
+		// Words of the same rank are in alphabetical order
+		q.Answers = append(q.Answers, ranked.Word)
+	}
+
+	sort.SliceStable(questions, func(i, j int) bool {
+		if questions[i].Rank != questions[j].Rank {
+			return questions[i].Rank < questions[j].Rank
+		}
+		return questions[i].Alphagram < questions[j].Alphagram
+	})
+	for _, q := range questions {
+		l.byAlphagram[q.Alphagram] = q
+	}
+	l.byLength[length] = questions
+	return questions
+}
+
+// Question returns the question of an alphagram,
+// nil if no word of the lexicon has its letters
+func (l *Lexicon) Question(alphagram string) *Question {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	alphagram = Alphagram(alphagram)
+	l.questions(len([]rune(alphagram)))
+	return l.byAlphagram[alphagram]
+}
+
+// Check checks the guesses to a question: a guess is correct if it is a
+// word of the DAWG with the letters of the alphagram. Repeated guesses
+// are only counted once.
+func (l *Lexicon) Check(q *Question, guesses []string) *Answer {
+	answer := &Answer{Alphagram: q.Alphagram}
+	found := make(map[string]bool)
+	for _, guess := range guesses {
+		guess = strings.ToLower(guess)
+		if found[guess] {
+			continue
+		}
+		found[guess] = true
+		if Alphagram(guess) == q.Alphagram && l.DAWG.IsWord(guess) {
+			answer.Correct = append(answer.Correct, guess)
+		} else {
+			answer.Wrong = append(answer.Wrong, guess)
+		}
+	}
+	for _, word := range q.Answers {
+		if !found[word] {
+			answer.Missed = append(answer.Missed, word)
+		}
+	}
+	return answer
+}
+
+// IsRight returns true if every answer was found without wrong guesses
+func (a *Answer) IsRight() bool {
+	return len(a.Missed) == 0 && len(a.Wrong) == 0
+}
@@ -0,0 +1,92 @@
+package study
+
+import (
+	"sort"
+	"time"
+)
+
+// Intervals are the delays before the alphagrams of each Leitner box
+// are asked again. A right answer moves the card to the next box,
+// a wrong one back to the first box.
+var Intervals = []time.Duration{
+	10 * time.Minute,
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+}
+
+// Card is the spaced repetition state of an alphagram for a user
+type Card struct {
+	Alphagram string    `json:"alphagram"`
+	Box       int       `json:"box"`
+	Due       time.Time `json:"due"`
+	Right     int       `json:"right"`
+	Wrong     int       `json:"wrong"`
+}
+
+// Progress is the spaced repetition state of every
+// alphagram a user has been asked, by alphagram
+type Progress struct {
+	User  string           `json:"user"`
+	Cards map[string]*Card `json:"cards"`
+}
+
+// NewProgress returns the Progress of a user who has not studied yet
+func NewProgress(user string) *Progress {
+	return &Progress{
+		User:  user,
+		Cards: make(map[string]*Card),
+	}
+}
+
+// Review moves the card to its next box after a right answer, or back
+// to the first box after a wrong one, and schedules it accordingly
+func (c *Card) Review(right bool, now time.Time) {
+	if right {
+		c.Right++
+		c.Box = minInt(c.Box+1, len(Intervals)-1)
+	} else {
+		c.Wrong++
+		c.Box = 0
+	}
+	c.Due = now.Add(Intervals[c.Box])
+}
+
+// Record reviews the card of an alphagram, which
+// is added to the Progress if it was never asked
+func (p *Progress) Record(alphagram string, right bool, now time.Time) *Card {
+	card, ok := p.Cards[alphagram]
+	if !ok {
+		card = &Card{Alphagram: alphagram}
+		p.Cards[alphagram] = card
+	}
+	card.Review(right, now)
+	return card
+}
+
+// Due returns the cards due at the given time, the most overdue first
+func (p *Progress) Due(now time.Time) []*Card {
+	var due []*Card
+	for _, card := range p.Cards {
+		if !card.Due.After(now) {
+			due = append(due, card)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].Due.Equal(due[j].Due) {
+			return due[i].Due.Before(due[j].Due)
+		}
+		return due[i].Alphagram < due[j].Alphagram
+	})
+	return due
+}
+
+func minInt(i1, i2 int) int {
+	if i1 < i2 {
+		return i1
+	}
+	return i2
+}
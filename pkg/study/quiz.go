@@ -0,0 +1,133 @@
+package study
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNoQuestions is returned when no question can be asked
+	ErrNoQuestions = errors.New("no questions to ask")
+	// ErrSessionOver is returned when answering a finished session
+	ErrSessionOver = errors.New("session is over")
+)
+
+// Session is a series of questions asked to a user
+type Session struct {
+	ID        string      `json:"id"`
+	User      string      `json:"user"`
+	Length    int         `json:"length"`
+	Started   time.Time   `json:"started"`
+	Questions []*Question `json:"questions"`
+	// Answers are the answers to the first questions,
+	// the session being over once every question is answered
+	Answers []*Answer `json:"answers"`
+}
+
+// Quiz asks alphagram questions of a Lexicon, reviewing the due
+// alphagrams of the user before asking new ones, and keeps their
+// progress and their sessions in a Store
+type Quiz struct {
+	Lexicon *Lexicon
+	Store   Store
+	// FromRank and ToRank limit the new questions to the alphagrams
+	// ranked between them by probability, ToRank being unbounded
+	// if zero
+	FromRank, ToRank int
+}
+
+// NewQuiz returns a Quiz asking every alphagram of the Lexicon
+func NewQuiz(lexicon *Lexicon, store Store) *Quiz {
+	return &Quiz{
+		Lexicon: lexicon,
+		Store:   store,
+	}
+}
+
+// Start starts a session of up to count questions about words of the
+// given length. The alphagrams due for review come first, then the
+// ones the user has never been asked, from the most probable one.
+func (q *Quiz) Start(user string, length, count int, now time.Time) (*Session, error) {
+	progress, err := q.Store.LoadProgress(user)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		ID:      uuid.New().String(),
+		User:    user,
+		Length:  length,
+		Started: now,
+	}
+	for _, card := range progress.Due(now) {
+		if len(s.Questions) == count {
+			break
+		}
+		if len([]rune(card.Alphagram)) != length {
+			continue
+		}
+		// The alphagram may have left the lexicon since it was asked
+		if question := q.Lexicon.Question(card.Alphagram); question != nil {
+			s.Questions = append(s.Questions, question)
+		}
+	}
+	for _, question := range q.Lexicon.Questions(length) {
+		if len(s.Questions) == count || (q.ToRank > 0 && question.Rank > q.ToRank) {
+			break
+		}
+		if _, asked := progress.Cards[question.Alphagram]; !asked && question.Rank >= q.FromRank {
+			s.Questions = append(s.Questions, question)
+		}
+	}
+	if len(s.Questions) == 0 {
+		return nil, ErrNoQuestions
+	}
+	return s, q.Store.SaveSession(s)
+}
+
+// Resume returns a session saved by the Store, to go on with it
+func (q *Quiz) Resume(id string) (*Session, error) {
+	return q.Store.LoadSession(id)
+}
+
+// Answer checks the guesses to the current question of the session,
+// and records the answer in the progress of the user
+func (q *Quiz) Answer(s *Session, guesses []string, now time.Time) (*Answer, error) {
+	question := s.Current()
+	if question == nil {
+		return nil, ErrSessionOver
+	}
+	progress, err := q.Store.LoadProgress(s.User)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := q.Lexicon.Check(question, guesses)
+	progress.Record(question.Alphagram, answer.IsRight(), now)
+	s.Answers = append(s.Answers, answer)
+	if err := q.Store.SaveProgress(progress); err != nil {
+		return nil, err
+	}
+	return answer, q.Store.SaveSession(s)
+}
+
+// Current returns the question to answer, nil if the session is over
+func (s *Session) Current() *Question {
+	if len(s.Answers) >= len(s.Questions) {
+		return nil
+	}
+	return s.Questions[len(s.Answers)]
+}
+
+// Score returns the number of questions answered right,
+// and the number of questions answered so far
+func (s *Session) Score() (right, answered int) {
+	for _, answer := range s.Answers {
+		if answer.IsRight() {
+			right++
+		}
+	}
+	return right, len(s.Answers)
+}
@@ -0,0 +1,233 @@
+package study
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound is returned by a Store for a session it does not have
+var ErrNotFound = errors.New("not found")
+
+// Store persists the progress of the users and their sessions
+type Store interface {
+	// LoadProgress returns the progress of a user,
+	// a new Progress if they have not studied yet
+	LoadProgress(user string) (*Progress, error)
+	SaveProgress(p *Progress) error
+	// LoadSession returns the session with the given ID, or ErrNotFound
+	LoadSession(id string) (*Session, error)
+	SaveSession(s *Session) error
+	// Sessions returns the sessions of a user, from the oldest
+	Sessions(user string) ([]*Session, error)
+}
+
+// Make sure the stores implement the Store interface
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*FileStore)(nil)
+)
+
+// MemoryStore keeps the progress and the sessions in memory, as
+// JSON so that callers do not share them. It is safe for concurrent
+// use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	progress map[string][]byte
+	sessions map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		progress: make(map[string][]byte),
+		sessions: make(map[string][]byte),
+	}
+}
+
+// LoadProgress returns the progress of a user
+func (ms *MemoryStore) LoadProgress(user string) (*Progress, error) {
+	ms.mu.Lock()
+	data, ok := ms.progress[user]
+	ms.mu.Unlock()
+	if !ok {
+		return NewProgress(user), nil
+	}
+	var p Progress
+	return &p, json.Unmarshal(data, &p)
+}
+
+// SaveProgress saves the progress of a user
+func (ms *MemoryStore) SaveProgress(p *Progress) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.progress[p.User] = data
+	return nil
+}
+
+// LoadSession returns the session with the given ID
+func (ms *MemoryStore) LoadSession(id string) (*Session, error) {
+	ms.mu.Lock()
+	data, ok := ms.sessions[id]
+	ms.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var s Session
+	return &s, json.Unmarshal(data, &s)
+}
+
+// SaveSession saves a session
+func (ms *MemoryStore) SaveSession(s *Session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.sessions[s.ID] = data
+	return nil
+}
+
+// Sessions returns the sessions of a user
+func (ms *MemoryStore) Sessions(user string) ([]*Session, error) {
+	ms.mu.Lock()
+	ids := make([]string, 0, len(ms.sessions))
+	for id := range ms.sessions {
+		ids = append(ids, id)
+	}
+	ms.mu.Unlock()
+	return userSessions(ms, ids, user)
+}
+
+// FileStore keeps the progress and the sessions as JSON files in a
+// directory: progress/<user>.json and sessions/<id>.json. It is safe
+// for concurrent use by a single process.
+type FileStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// NewFileStore returns a FileStore in the directory,
+// which is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	for _, sub := range []string{"progress", "sessions"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+// path returns the path of a file of the store, escaping
+// the name so that any user name can be used
+func (fs *FileStore) path(sub, name string) string {
+	return filepath.Join(fs.Dir, sub, url.PathEscape(name)+".json")
+}
+
+// LoadProgress returns the progress of a user
+func (fs *FileStore) LoadProgress(user string) (*Progress, error) {
+	var p Progress
+	if err := fs.load(fs.path("progress", user), &p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NewProgress(user), nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SaveProgress saves the progress of a user
+func (fs *FileStore) SaveProgress(p *Progress) error {
+	return fs.save(fs.path("progress", p.User), p)
+}
+
+// LoadSession returns the session with the given ID
+func (fs *FileStore) LoadSession(id string) (*Session, error) {
+	var s Session
+	if err := fs.load(fs.path("sessions", id), &s); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveSession saves a session
+func (fs *FileStore) SaveSession(s *Session) error {
+	return fs.save(fs.path("sessions", s.ID), s)
+}
+
+// Sessions returns the sessions of a user
+func (fs *FileStore) Sessions(user string) ([]*Session, error) {
+	entries, err := os.ReadDir(filepath.Join(fs.Dir, "sessions"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+		if id, err := url.PathUnescape(name[:len(name)-len(".json")]); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return userSessions(fs, ids, user)
+}
+
+func (fs *FileStore) load(path string, v interface{}) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// save writes the file through a temporary file, so
+// that it is never left half written
+func (fs *FileStore) save(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// userSessions loads the sessions with the given IDs
+// and returns those of the user, from the oldest
+func userSessions(store Store, ids []string, user string) ([]*Session, error) {
+	var sessions []*Session
+	for _, id := range ids {
+		s, err := store.LoadSession(id)
+		if err != nil {
+			return nil, err
+		}
+		if s.User == user {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Started.Before(sessions[j].Started)
+	})
+	return sessions, nil
+}
@@ -0,0 +1,145 @@
+package study
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"scrabble/pkg/scrabble"
+)
+
+// newTestLexicon returns a lexicon of a few words, drawn from a
+// small bag so that the combinations are easy to count
+func newTestLexicon() *Lexicon {
+	dawg := scrabble.NewDawg(&scrabble.Dictionary{Words: []string{
+		"ab", "at", "ba", "be", "ta", "bat", "bet", "tab", "abet", "bate",
+		"beat", "bats", "best", "bets", "stab", "tabs", "abets", "baste", "beast", "beats",
+	}})
+	tileSet := &scrabble.TileSet{
+		Count: map[rune]int{'a': 3, 'b': 1, 'e': 2, 's': 2, 't': 2},
+	}
+	return NewLexicon(dawg, tileSet)
+}
+
+func TestQuestions(t *testing.T) {
+	l := newTestLexicon()
+	var alphagrams []string
+	for _, q := range l.Questions(2) {
+		alphagrams = append(alphagrams, q.Alphagram)
+	}
+	// at can be drawn in 3*2 ways, ab in 3 and be in 2
+	if got := strings.Join(alphagrams, " "); got != "at ab be" {
+		t.Errorf("got alphagrams %q, want %q", got, "at ab be")
+	}
+
+	q := l.Question("ta")
+	if q == nil || q.Rank != 1 || q.Combinations != 6 || !reflect.DeepEqual(q.Answers, []string{"at", "ta"}) {
+		t.Fatalf("got question %+v for ta", q)
+	}
+	// Anagrams share their rank, as in the word finder
+	if q := l.Question("ba"); q == nil || q.Rank != 3 {
+		t.Errorf("got question %+v for ba, want rank 3", q)
+	}
+	for length := 2; length <= 5; length++ {
+		for _, ranked := range l.DAWG.WordsByProbability(length, l.TileSet) {
+			if q := l.Question(ranked.Word); q == nil || q.Rank != ranked.Rank {
+				t.Errorf("got question %+v for %s, want rank %d", q, ranked.Word, ranked.Rank)
+			}
+		}
+	}
+	if q := l.Question("abest"); q == nil || !reflect.DeepEqual(q.Answers, []string{"abets", "baste", "beast", "beats"}) {
+		t.Errorf("got question %+v for abest", q)
+	}
+	if q := l.Question("xyz"); q != nil {
+		t.Errorf("got question %+v for xyz, want none", q)
+	}
+
+	answer := l.Check(q, []string{"TA", "xx", "ta"})
+	want := &Answer{Alphagram: "at", Correct: []string{"ta"}, Missed: []string{"at"}, Wrong: []string{"xx"}}
+	if !reflect.DeepEqual(answer, want) {
+		t.Errorf("got answer %+v, want %+v", answer, want)
+	}
+	if answer.IsRight() {
+		t.Error("the answer should be wrong")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := NewProgress("ann")
+	if card := p.Record("at", true, now); card.Box != 1 || !card.Due.Equal(now.Add(Intervals[1])) {
+		t.Errorf("got card %+v after a right answer", card)
+	}
+	p.Record("ab", false, now)
+	p.Record("at", true, now)
+	if card := p.Record("at", false, now); card.Box != 0 || card.Right != 2 || card.Wrong != 1 {
+		t.Errorf("got card %+v after a wrong answer", card)
+	}
+	if due := p.Due(now); len(due) != 0 {
+		t.Errorf("got %d due cards, want none", len(due))
+	}
+	if due := p.Due(now.Add(time.Hour)); len(due) != 2 || due[0].Alphagram != "ab" {
+		t.Errorf("got due cards %+v, want ab and at", due)
+	}
+}
+
+func TestQuiz(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, store := range map[string]Store{"memory": NewMemoryStore(), "file": fileStore} {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			quiz := NewQuiz(newTestLexicon(), store)
+			s, err := quiz.Start("ann", 2, 2, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, guesses := range [][]string{{"at", "ta"}, {"ab"}} {
+				if _, err := quiz.Answer(s, guesses, now); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := quiz.Answer(s, nil, now); err != ErrSessionOver {
+				t.Errorf("got error %v, want %v", err, ErrSessionOver)
+			}
+			if right, answered := s.Score(); right != 1 || answered != 2 {
+				t.Errorf("got score %d of %d, want 1 of 2", right, answered)
+			}
+
+			// The missed ab is asked again before the new be,
+			// while at is not due yet
+			next, err := quiz.Start("ann", 2, 5, now.Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var alphagrams []string
+			for _, q := range next.Questions {
+				alphagrams = append(alphagrams, q.Alphagram)
+			}
+			if got := strings.Join(alphagrams, " "); got != "ab be" {
+				t.Errorf("got alphagrams %q, want %q", got, "ab be")
+			}
+
+			sessions, err := store.Sessions("ann")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sessions) != 2 || sessions[0].ID != s.ID || sessions[1].ID != next.ID {
+				t.Fatalf("got %d sessions, want the 2 sessions in order", len(sessions))
+			}
+			resumed, err := quiz.Resume(s.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(resumed.Answers, s.Answers) {
+				t.Errorf("got answers %+v, want %+v", resumed.Answers, s.Answers)
+			}
+			if _, err := quiz.Resume("missing"); err != ErrNotFound {
+				t.Errorf("got error %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}